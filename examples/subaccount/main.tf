@@ -16,4 +16,6 @@ resource "logzio_subaccount" "my_subaccount" {
   ]
   frequency_minutes = 3
   utilization_enabled = true
+  # deletion_protection defaults to true, which makes terraform destroy fail. Disable it to allow deleting the sub account.
+  deletion_protection = false
 }
@@ -0,0 +1,45 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const adoptExisting = "adopt_existing"
+
+// adoptExistingSchema is the per-resource override of the provider's adopt_existing setting
+func adoptExistingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "If true, create takes ownership of an existing object with the same natural key instead of creating a new one. Overrides the provider's adopt_existing setting.",
+	}
+}
+
+// shouldAdoptExisting returns the resource's adopt_existing if it's set in the config, otherwise the provider's setting
+func shouldAdoptExisting(d *schema.ResourceData, m interface{}) bool {
+	if adopt, ok := d.GetOkExists(adoptExisting); ok {
+		return adopt.(bool)
+	}
+
+	return m.(Config).adoptExisting
+}
+
+// findIdToAdopt returns the id of the single existing object that matches the natural key, or an empty string if none matches.
+// Ambiguous matches are refused, since we can't tell which object should be managed.
+func findIdToAdopt(ctx context.Context, objectType string, key string, value string, matchingIds []string) (string, error) {
+	switch len(matchingIds) {
+	case 0:
+		tflog.Info(ctx, fmt.Sprintf("no existing %s with %s %q to adopt, creating a new one", objectType, key, value))
+		return "", nil
+	case 1:
+		tflog.Info(ctx, fmt.Sprintf("adopting existing %s %s with %s %q", objectType, matchingIds[0], key, value))
+		return matchingIds[0], nil
+	default:
+		return "", fmt.Errorf("found %d existing %ss with %s %q (ids: %v), refusing to adopt. Import the one to manage, or set %s to false",
+			len(matchingIds), objectType, key, value, matchingIds, adoptExisting)
+	}
+}
@@ -0,0 +1,53 @@
+package logzio
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAdoptExisting_ShouldAdoptExisting(t *testing.T) {
+	testSchema := map[string]*schema.Schema{
+		grafanaFolderTitle: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		adoptExisting: adoptExistingSchema(),
+	}
+
+	notSet := schema.TestResourceDataRaw(t, testSchema, map[string]interface{}{grafanaFolderTitle: "folder"})
+	if !shouldAdoptExisting(notSet, Config{adoptExisting: true}) {
+		t.Error("expected the provider setting to be used when the resource doesn't set adopt_existing")
+	}
+
+	overridden := schema.TestResourceDataRaw(t, testSchema, map[string]interface{}{grafanaFolderTitle: "folder", adoptExisting: false})
+	if shouldAdoptExisting(overridden, Config{adoptExisting: true}) {
+		t.Error("expected the resource's adopt_existing to override the provider setting")
+	}
+
+	enabled := schema.TestResourceDataRaw(t, testSchema, map[string]interface{}{grafanaFolderTitle: "folder", adoptExisting: true})
+	if !shouldAdoptExisting(enabled, Config{}) {
+		t.Error("expected the resource's adopt_existing to enable adoption")
+	}
+}
+
+func TestAdoptExisting_FindIdToAdopt(t *testing.T) {
+	ctx := context.Background()
+
+	id, err := findIdToAdopt(ctx, "grafana folder", grafanaFolderTitle, "folder", nil)
+	if err != nil || id != "" {
+		t.Errorf("expected no id to adopt without matches, got %q, %v", id, err)
+	}
+
+	id, err = findIdToAdopt(ctx, "grafana folder", grafanaFolderTitle, "folder", []string{"abc"})
+	if err != nil || id != "abc" {
+		t.Errorf("expected to adopt the single match, got %q, %v", id, err)
+	}
+
+	_, err = findIdToAdopt(ctx, "grafana folder", grafanaFolderTitle, "folder", []string{"abc", "def"})
+	if err == nil || !regexp.MustCompile(`found 2 existing grafana folders .* refusing to adopt`).MatchString(err.Error()) {
+		t.Errorf("expected ambiguous matches to be refused, got %v", err)
+	}
+}
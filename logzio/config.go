@@ -1,6 +1,7 @@
 package logzio
 
 type Config struct {
-	apiToken string
-	baseUrl  string
+	apiToken      string
+	baseUrl       string
+	adoptExisting bool
 }
@@ -0,0 +1,189 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logzio/logzio_terraform_client/sub_accounts"
+)
+
+const (
+	accountUtilizationDays           = "days"
+	accountUtilizationAccounts       = "accounts"
+	accountUtilizationCurrentDailyGb = "current_daily_gb"
+	accountUtilizationDailyUsage     = "daily_usage"
+	accountUtilizationDate           = "date"
+	accountUtilizationBytes          = "bytes"
+	accountUtilizationGb             = "gb"
+
+	accountUtilizationDefaultDays = 7
+	bytesInGb                     = 1024 * 1024 * 1024
+
+	// Used as the id when the datasource isn't filtered to a single account
+	accountUtilizationAllAccountsId = "account_utilization"
+)
+
+func dataSourceAccountUtilization() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountUtilizationRead,
+		Schema: map[string]*schema.Schema{
+			subAccountId: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			subAccountName: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			accountUtilizationDays: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      accountUtilizationDefaultDays,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			accountUtilizationAccounts: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						subAccountId: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						subAccountName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						accountUtilizationCurrentDailyGb: {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						subAccountMaxDailyGB: {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						subAccountReservedDailyGb: {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						subAccountSoftLimitGB: {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						subAccountFlexible: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						subAccountsIsCapped: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						accountUtilizationDailyUsage: {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									accountUtilizationDate: {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									accountUtilizationBytes: {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									accountUtilizationGb: {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountUtilizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	subAccounts, err := subAccountClient(m).ListDetailedSubAccounts()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	accountId, filterById := d.GetOk(subAccountId)
+	accountName, filterByName := d.GetOk(subAccountName)
+	days := d.Get(accountUtilizationDays).(int)
+
+	accounts := make([]interface{}, 0)
+	for _, subAccount := range subAccounts {
+		if filterById && int(subAccount.Account.AccountId) != accountId.(int) {
+			continue
+		}
+		if filterByName && subAccount.Account.AccountName != accountName.(string) {
+			continue
+		}
+
+		accounts = append(accounts, flattenAccountUtilization(subAccount, days))
+	}
+
+	d.SetId(accountUtilizationAllAccountsId)
+	if filterById || filterByName {
+		if len(accounts) == 0 {
+			return diag.FromErr(fmt.Errorf("couldn't find sub-account with specified attributes"))
+		}
+
+		d.SetId(strconv.Itoa(accounts[0].(map[string]interface{})[subAccountId].(int)))
+	}
+
+	d.Set(accountUtilizationAccounts, accounts)
+	return nil
+}
+
+// flattenAccountUtilization returns the account's limits and its usage of the last days, oldest first
+func flattenAccountUtilization(subAccount sub_accounts.DetailedSubAccount, days int) map[string]interface{} {
+	usage := append([]sub_accounts.LHDailyCount{}, subAccount.DailyUsagesList.Usage...)
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].Date < usage[j].Date
+	})
+
+	if len(usage) > days {
+		usage = usage[len(usage)-days:]
+	}
+
+	dailyUsage := make([]interface{}, 0, len(usage))
+	for _, day := range usage {
+		dailyUsage = append(dailyUsage, map[string]interface{}{
+			accountUtilizationDate:  int(day.Date),
+			accountUtilizationBytes: int(day.Bytes),
+			accountUtilizationGb:    bytesToGb(day.Bytes),
+		})
+	}
+
+	// The current day is the most recent one in the usage list
+	currentDailyGb := 0.0
+	if len(usage) > 0 {
+		currentDailyGb = bytesToGb(usage[len(usage)-1].Bytes)
+	}
+
+	return map[string]interface{}{
+		subAccountId:                     int(subAccount.Account.AccountId),
+		subAccountName:                   subAccount.Account.AccountName,
+		accountUtilizationCurrentDailyGb: currentDailyGb,
+		subAccountMaxDailyGB:             float64(subAccount.Account.MaxDailyGB),
+		subAccountReservedDailyGb:        float64(subAccount.Account.ReservedDailyGB),
+		subAccountSoftLimitGB:            float64(subAccount.SoftLimitGB),
+		subAccountFlexible:               subAccount.Account.Flexible,
+		subAccountsIsCapped:              subAccount.IsCapped,
+		accountUtilizationDailyUsage:     dailyUsage,
+	}
+}
+
+func bytesToGb(bytes int64) float64 {
+	return float64(bytes) / bytesInGb
+}
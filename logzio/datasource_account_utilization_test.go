@@ -0,0 +1,115 @@
+package logzio
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_client/sub_accounts"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccDataSourceAccountUtilization(t *testing.T) {
+	dataSourceName := "data.logzio_account_utilization.utilization_by_name"
+	email := os.Getenv(envLogzioEmail)
+	accountName := "test_account_utilization_" + getRandomId()
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckEmail(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountUtilizationDataSourceConfig(email, accountName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "accounts.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "accounts.0.account_name", accountName),
+					resource.TestCheckResourceAttr(dataSourceName, "accounts.0.max_daily_gb", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "accounts.0.account_id",
+						"logzio_subaccount.utilization_subaccount", "account_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "accounts.0.current_daily_gb"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAccountUtilization_NotFound(t *testing.T) {
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "logzio_account_utilization" "utilization_by_name" {
+  account_name = "name_not_exist"
+}
+`,
+				ExpectError: regexp.MustCompile("couldn't find sub-account with specified attributes"),
+			},
+		},
+	})
+}
+
+func TestAccountUtilization_FlattenAccountUtilization(t *testing.T) {
+	subAccount := sub_accounts.DetailedSubAccount{
+		Account: sub_accounts.AccountView{AccountId: 1, AccountName: "test", MaxDailyGB: 5},
+		DailyUsagesList: sub_accounts.DailyUsagesListObject{
+			Usage: []sub_accounts.LHDailyCount{
+				{Date: 300, Bytes: 3 * bytesInGb},
+				{Date: 100, Bytes: 1 * bytesInGb},
+				{Date: 200, Bytes: bytesInGb / 2},
+			},
+		},
+		IsCapped: true,
+	}
+
+	utilization := flattenAccountUtilization(subAccount, 2)
+	if utilization[accountUtilizationCurrentDailyGb].(float64) != 3 {
+		t.Errorf("expected the current daily gb to be the most recent day's, got %v", utilization[accountUtilizationCurrentDailyGb])
+	}
+	if !utilization[subAccountsIsCapped].(bool) {
+		t.Error("expected the account to be capped")
+	}
+
+	dailyUsage := utilization[accountUtilizationDailyUsage].([]interface{})
+	if len(dailyUsage) != 2 {
+		t.Fatalf("expected the usage of the last 2 days, got %d days", len(dailyUsage))
+	}
+	if date := dailyUsage[0].(map[string]interface{})[accountUtilizationDate].(int); date != 200 {
+		t.Errorf("expected the usage to start at the oldest of the last days, got %d", date)
+	}
+	if gb := dailyUsage[0].(map[string]interface{})[accountUtilizationGb].(float64); gb != 0.5 {
+		t.Errorf("unexpected gb: %v", gb)
+	}
+
+	if utilization = flattenAccountUtilization(sub_accounts.DetailedSubAccount{}, 7); utilization[accountUtilizationCurrentDailyGb].(float64) != 0 {
+		t.Errorf("expected no usage, got %v", utilization[accountUtilizationCurrentDailyGb])
+	}
+}
+
+func testAccAccountUtilizationDataSourceConfig(email string, accountName string) string {
+	return fmt.Sprintf(`resource "logzio_subaccount" "utilization_subaccount" {
+  deletion_protection = false
+  email = "%s"
+  account_name = "%s"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_accounts = []
+}
+
+data "logzio_account_utilization" "utilization_by_name" {
+  account_name = logzio_subaccount.utilization_subaccount.account_name
+  days = 3
+  depends_on = [logzio_subaccount.utilization_subaccount]
+}
+`, email, accountName)
+}
@@ -0,0 +1,401 @@
+package logzio
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logzio/logzio_terraform_client/drop_filters"
+	"github.com/logzio/logzio_terraform_client/drop_metrics"
+)
+
+const (
+	dropFilterPreviewSampleFile     = "sample_file"
+	dropFilterPreviewSampleFormat   = "sample_format"
+	dropFilterPreviewMaxExamples    = "max_examples"
+	dropFilterPreviewTotalRecords   = "total_records"
+	dropFilterPreviewMatchedRecords = "matched_records"
+	dropFilterPreviewExamples       = "examples"
+
+	dropFilterPreviewFormatNdjson     = "ndjson"
+	dropFilterPreviewFormatPrometheus = "prometheus"
+
+	dropFilterPreviewLogTypeField      = "type"
+	dropFilterPreviewMaxLineSize       = 1024 * 1024
+	dropFilterPreviewDefaultMaxExample = 5
+)
+
+// dataSourceDropFilterPreview evaluates drop filter conditions or drop metrics filters against a local sample file.
+// Nothing is sent to Logz.io, the data source only reports what the rule would drop.
+func dataSourceDropFilterPreview() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDropFilterPreviewRead,
+		Schema: map[string]*schema.Schema{
+			dropFilterPreviewSampleFile: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			dropFilterPreviewSampleFormat: {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  dropFilterPreviewFormatNdjson,
+				ValidateFunc: validation.StringInSlice(
+					[]string{dropFilterPreviewFormatNdjson, dropFilterPreviewFormatPrometheus},
+					false),
+			},
+			dropFilterLogType: {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{dropMetricsFilters},
+			},
+			dropFilterFieldConditions: {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{dropMetricsFilters},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dropFilterFieldName: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						dropFilterValue: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			dropMetricsFilters: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dropMetricsExpressionLabelName: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						dropMetricsExpressionLabelValue: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						dropMetricsExpressionCondition: {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice(
+								[]string{
+									drop_metrics.ComparisonEq,
+									drop_metrics.ComparisonNotEq,
+									drop_metrics.ComparisonRegexMatch,
+									drop_metrics.ComparisonRegexNoMatch,
+								}, false),
+						},
+					},
+				},
+			},
+			dropFilterPreviewMaxExamples: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      dropFilterPreviewDefaultMaxExample,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			dropFilterPreviewTotalRecords: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			dropFilterPreviewMatchedRecords: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			dropFilterPreviewExamples: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceDropFilterPreviewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sampleFile := d.Get(dropFilterPreviewSampleFile).(string)
+	sampleFormat := d.Get(dropFilterPreviewSampleFormat).(string)
+	fieldConditions := d.Get(dropFilterFieldConditions).([]interface{})
+	metricsFilters := d.Get(dropMetricsFilters).([]interface{})
+
+	var matcher func(line string) (bool, error)
+	switch sampleFormat {
+	case dropFilterPreviewFormatNdjson:
+		if len(metricsFilters) > 0 {
+			return diag.Errorf("%s can only be evaluated against a %s sample file", dropMetricsFilters, dropFilterPreviewFormatPrometheus)
+		}
+		if len(fieldConditions) == 0 {
+			return diag.Errorf("%s must be set when %s is %s", dropFilterFieldConditions, dropFilterPreviewSampleFormat, dropFilterPreviewFormatNdjson)
+		}
+		logType := d.Get(dropFilterLogType).(string)
+		conditions := getFieldConditionsList(fieldConditions)
+		matcher = func(line string) (bool, error) {
+			return matchDropFilterLog(line, logType, conditions)
+		}
+	case dropFilterPreviewFormatPrometheus:
+		if len(fieldConditions) > 0 {
+			return diag.Errorf("%s can only be evaluated against a %s sample file", dropFilterFieldConditions, dropFilterPreviewFormatNdjson)
+		}
+		if len(metricsFilters) == 0 {
+			return diag.Errorf("%s must be set when %s is %s", dropMetricsFilters, dropFilterPreviewSampleFormat, dropFilterPreviewFormatPrometheus)
+		}
+		expressions, err := compileDropMetricsExpressions(metricsFilters)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		matcher = func(line string) (bool, error) {
+			labels, err := parsePrometheusSample(line)
+			if err != nil {
+				return false, err
+			}
+			return matchDropMetricsLabels(labels, expressions), nil
+		}
+	}
+
+	total, matched, examples, err := previewDropFilter(sampleFile, sampleFormat, d.Get(dropFilterPreviewMaxExamples).(int), matcher)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(sampleFile)
+	d.Set(dropFilterPreviewTotalRecords, total)
+	d.Set(dropFilterPreviewMatchedRecords, matched)
+	d.Set(dropFilterPreviewExamples, examples)
+	return nil
+}
+
+// previewDropFilter reads the sample file line by line and counts the records the matcher would drop.
+// Empty lines are skipped, and so are comment lines in prometheus exposition files.
+func previewDropFilter(sampleFile string, sampleFormat string, maxExamples int, matcher func(line string) (bool, error)) (int, int, []string, error) {
+	file, err := os.Open(sampleFile)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("could not open sample file: %v", err)
+	}
+	defer file.Close()
+
+	total, matched := 0, 0
+	examples := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), dropFilterPreviewMaxLineSize)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || (sampleFormat == dropFilterPreviewFormatPrometheus && strings.HasPrefix(line, "#")) {
+			continue
+		}
+
+		total++
+		isMatch, err := matcher(line)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("could not parse line %d of %s: %v", lineNumber, sampleFile, err)
+		}
+		if isMatch {
+			matched++
+			if len(examples) < maxExamples {
+				examples = append(examples, line)
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return 0, 0, nil, fmt.Errorf("could not read sample file: %v", err)
+	}
+
+	return total, matched, examples, nil
+}
+
+// matchDropFilterLog checks whether a single JSON log would be dropped by a drop filter.
+// The log type is matched against the log's type field, and every field condition must match.
+// Condition values are typed the same way they are sent to the API, so "200" matches the number 200 but not the string "200".
+func matchDropFilterLog(line string, logType string, conditions []drop_filters.FieldConditionObject) (bool, error) {
+	var log map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &log); err != nil {
+		return false, err
+	}
+
+	if logType != "" {
+		if value, ok := lookupLogField(log, dropFilterPreviewLogTypeField); !ok || value != logType {
+			return false, nil
+		}
+	}
+
+	for _, condition := range conditions {
+		value, ok := lookupLogField(log, condition.FieldName)
+		if !ok || !reflect.DeepEqual(value, condition.Value) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// lookupLogField gets a field from a log, supporting both flat dotted keys and nested objects.
+func lookupLogField(log map[string]interface{}, fieldName string) (interface{}, bool) {
+	if value, ok := log[fieldName]; ok {
+		return value, true
+	}
+
+	parts := strings.SplitN(fieldName, ".", 2)
+	if len(parts) < 2 {
+		return nil, false
+	}
+
+	nested, ok := log[parts[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	return lookupLogField(nested, parts[1])
+}
+
+type dropMetricsPreviewExpression struct {
+	drop_metrics.FilterExpression
+	regex *regexp.Regexp
+}
+
+// compileDropMetricsExpressions builds the drop metrics expressions from the schema.
+// Regular expressions are fully anchored, the same as Prometheus label matchers.
+func compileDropMetricsExpressions(filters []interface{}) ([]dropMetricsPreviewExpression, error) {
+	expressions := make([]dropMetricsPreviewExpression, 0, len(filters))
+	for i, raw := range filters {
+		filter := raw.(map[string]interface{})
+		expression := dropMetricsPreviewExpression{
+			FilterExpression: drop_metrics.FilterExpression{
+				Name:             filter[dropMetricsExpressionLabelName].(string),
+				Value:            filter[dropMetricsExpressionLabelValue].(string),
+				ComparisonFilter: filter[dropMetricsExpressionCondition].(string),
+			},
+		}
+
+		if expression.ComparisonFilter == drop_metrics.ComparisonRegexMatch || expression.ComparisonFilter == drop_metrics.ComparisonRegexNoMatch {
+			regex, err := regexp.Compile("^(?:" + expression.Value + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex in %s.%d.%s: %v", dropMetricsFilters, i, dropMetricsExpressionLabelValue, err)
+			}
+			expression.regex = regex
+		}
+
+		expressions = append(expressions, expression)
+	}
+
+	return expressions, nil
+}
+
+// matchDropMetricsLabels checks whether a series would be dropped by a drop metrics filter.
+// All expressions must match (the AND operator). A missing label is treated as an empty value.
+func matchDropMetricsLabels(labels map[string]string, expressions []dropMetricsPreviewExpression) bool {
+	for _, expression := range expressions {
+		value := labels[expression.Name]
+		var isMatch bool
+		switch expression.ComparisonFilter {
+		case drop_metrics.ComparisonEq:
+			isMatch = value == expression.Value
+		case drop_metrics.ComparisonNotEq:
+			isMatch = value != expression.Value
+		case drop_metrics.ComparisonRegexMatch:
+			isMatch = expression.regex.MatchString(value)
+		case drop_metrics.ComparisonRegexNoMatch:
+			isMatch = !expression.regex.MatchString(value)
+		}
+
+		if !isMatch {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parsePrometheusSample parses a single sample line of the Prometheus text exposition format,
+// e.g. http_requests_total{method="post",code="200"} 1027, and returns its labels.
+// The metric name is returned under the __name__ label.
+func parsePrometheusSample(line string) (map[string]string, error) {
+	labels := make(map[string]string)
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd <= 0 {
+		return nil, fmt.Errorf("expected a metric name followed by a value")
+	}
+
+	labels[metricNameLabel] = line[:nameEnd]
+	rest := line[nameEnd:]
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parsePrometheusLabels(rest[1:], labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.TrimSpace(rest) == "" {
+		return nil, fmt.Errorf("missing sample value for metric %s", labels[metricNameLabel])
+	}
+
+	return labels, nil
+}
+
+// parsePrometheusLabels parses the labels between the curly braces into labels, and returns what follows the closing brace.
+func parsePrometheusLabels(rest string, labels map[string]string) (string, error) {
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if strings.HasPrefix(rest, "}") {
+			return rest[1:], nil
+		}
+
+		equalsIndex := strings.Index(rest, "=")
+		if equalsIndex <= 0 {
+			return "", fmt.Errorf("expected a label name")
+		}
+
+		name := strings.TrimSpace(rest[:equalsIndex])
+		rest = strings.TrimLeft(rest[equalsIndex+1:], " \t")
+		if !strings.HasPrefix(rest, "\"") {
+			return "", fmt.Errorf("expected a quoted value for label %s", name)
+		}
+
+		var value strings.Builder
+		closed := false
+		i := 1
+		for ; i < len(rest); i++ {
+			c := rest[i]
+			if c == '"' {
+				closed = true
+				break
+			}
+			if c == '\\' && i+1 < len(rest) {
+				i++
+				switch rest[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(rest[i])
+				}
+				continue
+			}
+			value.WriteByte(c)
+		}
+
+		if !closed {
+			return "", fmt.Errorf("unterminated value for label %s", name)
+		}
+
+		labels[name] = value.String()
+		rest = strings.TrimLeft(rest[i+1:], " \t")
+		if strings.HasPrefix(rest, ",") {
+			rest = rest[1:]
+		} else if !strings.HasPrefix(rest, "}") {
+			return "", fmt.Errorf("expected , or } after label %s", name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logzio/logzio_terraform_client/drop_metrics"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccDataSourceDropFilterPreview_Logs(t *testing.T) {
+	dataSourceName := "data.logzio_drop_filter_preview.preview_logs"
+	sampleFile, err := filepath.Abs("testdata/fixtures/drop_filter_preview_logs.ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`data "logzio_drop_filter_preview" "preview_logs" {
+  sample_file  = "%s"
+  log_type     = "nginx"
+  max_examples = 2
+
+  field_conditions {
+    field_name = "status"
+    value      = "200"
+  }
+}
+`, sampleFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, dropFilterPreviewTotalRecords, "6"),
+					resource.TestCheckResourceAttr(dataSourceName, dropFilterPreviewMatchedRecords, "3"),
+					resource.TestCheckResourceAttr(dataSourceName, dropFilterPreviewExamples+".#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDropFilterPreview_Metrics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceDropFilterPreview().Schema, map[string]interface{}{
+		dropFilterPreviewSampleFile:   "testdata/fixtures/drop_filter_preview_metrics.prom",
+		dropFilterPreviewSampleFormat: dropFilterPreviewFormatPrometheus,
+		dropMetricsFilters: []interface{}{
+			map[string]interface{}{
+				dropMetricsExpressionLabelName:  metricNameLabel,
+				dropMetricsExpressionLabelValue: "http_requests_total",
+				dropMetricsExpressionCondition:  drop_metrics.ComparisonEq,
+			},
+			map[string]interface{}{
+				dropMetricsExpressionLabelName:  "code",
+				dropMetricsExpressionLabelValue: "400",
+				dropMetricsExpressionCondition:  drop_metrics.ComparisonNotEq,
+			},
+			map[string]interface{}{
+				dropMetricsExpressionLabelName:  "path",
+				dropMetricsExpressionLabelValue: "/api",
+				dropMetricsExpressionCondition:  drop_metrics.ComparisonRegexNoMatch,
+			},
+			map[string]interface{}{
+				dropMetricsExpressionLabelName:  "path",
+				dropMetricsExpressionLabelValue: "/heal",
+				dropMetricsExpressionCondition:  drop_metrics.ComparisonRegexNoMatch,
+			},
+		},
+	})
+
+	diags := dataSourceDropFilterPreviewRead(context.Background(), d, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// REGEX_NO_MATCH is anchored, so "/api" and "/heal" don't match "/api/v1" and "/health"
+	if total := d.Get(dropFilterPreviewTotalRecords).(int); total != 5 {
+		t.Errorf("expected 5 records, got %d", total)
+	}
+	if matched := d.Get(dropFilterPreviewMatchedRecords).(int); matched != 3 {
+		t.Errorf("expected 3 matched records, got %d", matched)
+	}
+}
+
+func TestDropFilterPreview_ParsePrometheusSample(t *testing.T) {
+	labels, err := parsePrometheusSample(`http_requests_total{method="get",note="a \"quoted\" value, with a comma"} 7`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		metricNameLabel: "http_requests_total",
+		"method":        "get",
+		"note":          `a "quoted" value, with a comma`,
+	}
+	if len(labels) != len(expected) {
+		t.Fatalf("expected labels %v, got %v", expected, labels)
+	}
+	for name, value := range expected {
+		if labels[name] != value {
+			t.Errorf("expected label %s to be %q, got %q", name, value, labels[name])
+		}
+	}
+
+	if _, err = parsePrometheusSample(`http_requests_total{method="get"`); err == nil {
+		t.Error("expected an error for a sample without a closing brace")
+	}
+	if _, err = parsePrometheusSample(`http_requests_total{method="get"}`); err == nil {
+		t.Error("expected an error for a sample without a value")
+	}
+}
+
+func TestDropFilterPreview_MatchDropFilterLog(t *testing.T) {
+	conditions := getFieldConditionsList([]interface{}{
+		map[string]interface{}{dropFilterFieldName: "status", dropFilterValue: "200"},
+		map[string]interface{}{dropFilterFieldName: "request.path", dropFilterValue: "/health"},
+	})
+
+	cases := map[string]bool{
+		`{"type":"nginx","status":200,"request":{"path":"/health"}}`: true,
+		`{"type":"nginx","status":200,"request.path":"/health"}`:     true,
+		`{"type":"nginx","status":"200","request.path":"/health"}`:   false,
+		`{"type":"java","status":200,"request.path":"/health"}`:      false,
+		`{"type":"nginx","status":200}`:                              false,
+	}
+
+	for line, expected := range cases {
+		isMatch, err := matchDropFilterLog(line, "nginx", conditions)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", line, err)
+		}
+		if isMatch != expected {
+			t.Errorf("expected match to be %t for %s", expected, line)
+		}
+	}
+}
@@ -92,6 +92,7 @@ func dataSourceLogShippingTokenRead(ctx context.Context, d *schema.ResourceData,
 	return diag.Errorf("couldn't find log shipping token with specified attributes")
 }
 
+// findLogShippingTokenByName returns the enabled or disabled token with the given name, or nil if there's none
 func findLogShippingTokenByName(name string, enabled bool, client *log_shipping_tokens.LogShippingTokensClient) (*log_shipping_tokens.LogShippingToken, error) {
 	retrieveRequest := log_shipping_tokens.RetrieveLogShippingTokensRequest{
 		Filter: log_shipping_tokens.ShippingTokensFilterRequest{Enabled: strconv.FormatBool(enabled)},
@@ -125,7 +126,7 @@ func findLogShippingTokenByName(name string, enabled bool, client *log_shipping_
 		totalRetrieved += currentlyRetrieved
 	}
 
-	return nil, fmt.Errorf("couldn't find log shipping token with specified attributes")
+	return nil, nil
 }
 
 func findTokenInResultsListByName(name string, tokens []log_shipping_tokens.LogShippingToken) *log_shipping_tokens.LogShippingToken {
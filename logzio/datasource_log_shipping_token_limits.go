@@ -0,0 +1,56 @@
+package logzio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	logShippingTokenLimitsMaxAllowedTokens = "max_allowed_tokens"
+	logShippingTokenLimitsEnabledTokens    = "num_of_enabled_tokens"
+	logShippingTokenLimitsRemainingTokens  = "remaining_tokens"
+
+	// The limits are of the account, so the datasource has a single, fixed id
+	logShippingTokenLimitsId = "log_shipping_token_limits"
+)
+
+func dataSourceLogShippingTokenLimits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLogShippingTokenLimitsRead,
+		Schema: map[string]*schema.Schema{
+			logShippingTokenLimitsMaxAllowedTokens: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			logShippingTokenLimitsEnabledTokens: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			logShippingTokenLimitsRemainingTokens: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceLogShippingTokenLimitsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tokenLimits, err := logShippingTokenClient(m).GetLogShippingLimitsToken()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Disabled tokens don't count towards the limit
+	remaining := tokenLimits.MaxAllowedTokens - tokenLimits.NumOfEnabledTokens
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	d.SetId(logShippingTokenLimitsId)
+	d.Set(logShippingTokenLimitsMaxAllowedTokens, tokenLimits.MaxAllowedTokens)
+	d.Set(logShippingTokenLimitsEnabledTokens, tokenLimits.NumOfEnabledTokens)
+	d.Set(logShippingTokenLimitsRemainingTokens, remaining)
+	return nil
+}
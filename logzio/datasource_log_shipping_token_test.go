@@ -1,6 +1,7 @@
 package logzio
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"testing"
@@ -23,3 +24,30 @@ func TestAccDataSourceLogShippingToken(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceLogShippingToken_DisabledToken(t *testing.T) {
+	resourceName := "data.logzio_log_shipping_token.my_disabled_log_shipping_token_datasource"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(utils.ReadFixtureFromFile("create_log_shipping_token_datasource_disabled.tf"), true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				// No enabled token has the name after the token is disabled, so the disabled token is found
+				Config: fmt.Sprintf(utils.ReadFixtureFromFile("create_log_shipping_token_datasource_disabled.tf"), false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "my_disabled_token"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "token_id", "logzio_log_shipping_token.log_shipping_token_datasource_disabled", "token_id"),
+				),
+			},
+		},
+	})
+}
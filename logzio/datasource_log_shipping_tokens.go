@@ -0,0 +1,139 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logzio/logzio_terraform_client/log_shipping_tokens"
+)
+
+const (
+	logShippingTokensTokens = "tokens"
+	logShippingTokensTotal  = "total"
+)
+
+func dataSourceLogShippingTokens() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLogShippingTokensRead,
+		Schema: map[string]*schema.Schema{
+			logShippingTokenEnabled: {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			logShippingTokenName: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			logShippingTokensTotal: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			logShippingTokensTokens: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						logShippingTokenTokenId: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						logShippingTokenName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						logShippingTokenEnabled: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						logShippingTokenToken: {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						logShippingTokenUpdatedAt: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						logShippingTokenUpdatedBy: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						logShippingTokenCreatedAt: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						logShippingTokenCreatedBy: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLogShippingTokensRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	enabledValues := []bool{true, false}
+	enabled, err := optionalBoolPtr(d, logShippingTokenEnabled)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if enabled != nil {
+		enabledValues = []bool{*enabled}
+	}
+
+	name := d.Get(logShippingTokenName).(string)
+	client := logShippingTokenClient(m)
+	var tokens []log_shipping_tokens.LogShippingToken
+	for _, v := range enabledValues {
+		retrieved, err := retrieveAllLogShippingTokens(client, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, token := range retrieved {
+			if name == "" || token.Name == name {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Id < tokens[j].Id
+	})
+
+	d.SetId(fmt.Sprintf("log_shipping_tokens:%s:%s", optionalBoolString(enabled), name))
+	d.Set(logShippingTokensTotal, len(tokens))
+	d.Set(logShippingTokensTokens, flattenLogShippingTokens(tokens))
+	return nil
+}
+
+func flattenLogShippingTokens(tokens []log_shipping_tokens.LogShippingToken) []interface{} {
+	result := make([]interface{}, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, map[string]interface{}{
+			logShippingTokenTokenId:   int(token.Id),
+			logShippingTokenName:      token.Name,
+			logShippingTokenEnabled:   token.Enabled,
+			logShippingTokenToken:     token.Token,
+			logShippingTokenUpdatedAt: int(token.UpdatedAt),
+			logShippingTokenUpdatedBy: token.UpdatedBy,
+			logShippingTokenCreatedAt: int(token.CreatedAt),
+			logShippingTokenCreatedBy: token.CreatedBy,
+		})
+	}
+
+	return result
+}
+
+func optionalBoolString(b *bool) string {
+	if b == nil {
+		return "all"
+	}
+
+	return fmt.Sprintf("%t", *b)
+}
@@ -0,0 +1,53 @@
+package logzio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccDataSourceLogShippingTokenLimits(t *testing.T) {
+	resourceName := "data.logzio_log_shipping_token_limits.limits"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: utils.ReadFixtureFromFile("log_shipping_token_limits_datasource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, logShippingTokenLimitsMaxAllowedTokens),
+					resource.TestCheckResourceAttrSet(resourceName, logShippingTokenLimitsEnabledTokens),
+					resource.TestCheckResourceAttrSet(resourceName, logShippingTokenLimitsRemainingTokens),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceLogShippingTokens(t *testing.T) {
+	resourceName := "data.logzio_log_shipping_tokens.by_name"
+	tokenName := "tf_tokens_" + getRandomId()
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(utils.ReadFixtureFromFile("log_shipping_tokens_datasource.tf"), tokenName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, logShippingTokensTotal, "1"),
+					resource.TestCheckResourceAttr(resourceName, "tokens.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tokens.0.name", tokenName),
+					resource.TestCheckResourceAttr(resourceName, "tokens.0.enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "tokens.0.token_id",
+						"logzio_log_shipping_token.log_shipping_tokens_datasource", "token_id"),
+				),
+			},
+		},
+	})
+}
@@ -187,6 +187,7 @@ func TestAccDataSourceSubaccountNotExists(t *testing.T) {
 
 func testAccSubAccountDataSourceResource(email string, accountId int64, accountName string) string {
 	return fmt.Sprintf(`resource "logzio_subaccount" "subaccount_datasource" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = 2
@@ -221,6 +222,7 @@ func testAccCheckLogzioSubaccountDatasourceConfigNotExist() string {
 
 func testAccSubAccountWarmDataSourceResource(email string, accountId int64, accountName string) string {
 	return fmt.Sprintf(`resource "logzio_subaccount" "subaccount_datasource" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = 4
@@ -237,6 +239,7 @@ func testAccSubAccountWarmDataSourceResource(email string, accountId int64, acco
 
 func testAccSubAccountConsumptionDataSourceResource(email string, accountId int64, accountName string) string {
 	return fmt.Sprintf(`resource "logzio_subaccount" "subaccount_datasource" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = 4
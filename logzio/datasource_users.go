@@ -0,0 +1,145 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logzio/logzio_terraform_client/users"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+const (
+	usersUsernameRegex = "username_regex"
+	usersTotal         = "total"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			userAccountId: {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			userRole: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: utils.ValidateUserRoleUser,
+			},
+			userActive: {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			usersUsernameRegex: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			usersTotal: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			usersUsers: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						userId: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						userUsername: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						userFullName: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						userAccountId: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						userRole: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						userActive: {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	active, err := optionalBoolPtr(d, userActive)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	usernameRegex, err := regexp.Compile(d.Get(usersUsernameRegex).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The API returns all the users in a single response, so there are no pages to go through
+	list, err := usersClient(m).ListUsers()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	accountId := int32(d.Get(userAccountId).(int))
+	role := d.Get(userRole).(string)
+	matching := filterUsers(list, accountId, role, active, usernameRegex)
+
+	d.SetId(fmt.Sprintf("users:%d:%s:%s:%s", accountId, role, optionalBoolString(active), usernameRegex.String()))
+	d.Set(usersTotal, len(matching))
+	d.Set(usersUsers, flattenUsers(matching))
+	return nil
+}
+
+// filterUsers returns the users that match all the set filters, sorted by id
+func filterUsers(list []users.User, accountId int32, role string, active *bool, usernameRegex *regexp.Regexp) []users.User {
+	matching := make([]users.User, 0, len(list))
+	for _, user := range list {
+		if (accountId != 0 && user.AccountId != accountId) ||
+			(role != "" && user.Role != role) ||
+			(active != nil && user.Active != *active) ||
+			!usernameRegex.MatchString(user.UserName) {
+			continue
+		}
+
+		matching = append(matching, user)
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Id < matching[j].Id
+	})
+
+	return matching
+}
+
+func flattenUsers(list []users.User) []interface{} {
+	result := make([]interface{}, 0, len(list))
+	for _, user := range list {
+		result = append(result, map[string]interface{}{
+			userId:        int(user.Id),
+			userUsername:  user.UserName,
+			userFullName:  user.FullName,
+			userAccountId: int(user.AccountId),
+			userRole:      user.Role,
+			userActive:    user.Active,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,98 @@
+package logzio
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_client/users"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccDataSourceUsers(t *testing.T) {
+	dataSourceName := "data.logzio_users.test_users_datasource"
+	username := "test_datasource_users@tfacctest.com"
+	accountId, _ := strconv.ParseInt(os.Getenv(envLogzioAccountId), utils.BASE_10, utils.BITSIZE_64)
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioUsersDatasourceConfig(username, accountId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(dataSourceName, usersTotal, "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.username", username),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.role", users.UserRoleReadOnly),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.active", "true"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "users.0.id", "logzio_user.test_user_for_users_datasource", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUsers_FilterUsers(t *testing.T) {
+	list := []users.User{
+		{Id: 3, UserName: "c@example.com", AccountId: 1, Role: users.UserRoleReadOnly, Active: true},
+		{Id: 1, UserName: "a@example.com", AccountId: 1, Role: users.UserRoleReadOnly, Active: false},
+		{Id: 2, UserName: "b@other.com", AccountId: 1, Role: users.UserRoleAccountAdmin, Active: true},
+		{Id: 4, UserName: "d@example.com", AccountId: 2, Role: users.UserRoleReadOnly, Active: true},
+	}
+	inactive := false
+
+	cases := map[string]struct {
+		accountId     int32
+		role          string
+		active        *bool
+		usernameRegex string
+		expectedIds   []int32
+	}{
+		"no filters":     {expectedIds: []int32{1, 2, 3, 4}},
+		"account":        {accountId: 1, expectedIds: []int32{1, 2, 3}},
+		"role":           {accountId: 1, role: users.UserRoleReadOnly, expectedIds: []int32{1, 3}},
+		"inactive":       {active: &inactive, expectedIds: []int32{1}},
+		"username regex": {usernameRegex: "@example\\.com$", expectedIds: []int32{1, 3, 4}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			matching := filterUsers(list, tc.accountId, tc.role, tc.active, regexp.MustCompile(tc.usernameRegex))
+			ids := make([]int32, 0, len(matching))
+			for _, user := range matching {
+				ids = append(ids, user.Id)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expectedIds) {
+				t.Errorf("expected users %v, got %v", tc.expectedIds, ids)
+			}
+		})
+	}
+}
+
+func testAccCheckLogzioUsersDatasourceConfig(username string, accountId int64) string {
+	return fmt.Sprintf(`
+resource "logzio_user" "test_user_for_users_datasource" {
+  username = "%s"
+  fullname = "test users datasource"
+  account_id = %d
+  role = "USER_ROLE_READONLY"
+}
+
+data "logzio_users" "test_users_datasource" {
+  account_id = %d
+  role = "USER_ROLE_READONLY"
+  active = true
+  username_regex = "^test_datasource_users@"
+  depends_on = [logzio_user.test_user_for_users_datasource]
+}
+`, username, accountId, accountId)
+}
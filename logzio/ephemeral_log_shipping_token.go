@@ -0,0 +1,126 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/logzio/logzio_terraform_client/log_shipping_tokens"
+)
+
+// ephemeralLogShippingToken looks up a log shipping token without storing it in the plan or state
+type ephemeralLogShippingToken struct {
+	config Config
+}
+
+type ephemeralLogShippingTokenModel struct {
+	TokenId types.Int64  `tfsdk:"token_id"`
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	Token   types.String `tfsdk:"token"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralLogShippingToken{}
+
+func newEphemeralLogShippingToken() ephemeral.EphemeralResource {
+	return &ephemeralLogShippingToken{}
+}
+
+func (e *ephemeralLogShippingToken) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = resourceLogShippingTokenType
+}
+
+func (e *ephemeralLogShippingToken) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a log shipping token by its id or name, without storing the token in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			logShippingTokenTokenId: schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The log shipping token's ID. Either token_id or name must be set.",
+			},
+			logShippingTokenName: schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The log shipping token's name. Enabled tokens are searched before disabled tokens.",
+			},
+			logShippingTokenEnabled: schema.BoolAttribute{
+				Computed: true,
+			},
+			logShippingTokenToken: schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (e *ephemeralLogShippingToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	configureEphemeral(req, resp, &e.config)
+}
+
+func (e *ephemeralLogShippingToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralLogShippingTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := log_shipping_tokens.New(e.config.apiToken, e.config.baseUrl)
+	if err != nil {
+		resp.Diagnostics.AddError("could not create log shipping tokens client", err.Error())
+		return
+	}
+
+	var token *log_shipping_tokens.LogShippingToken
+	switch {
+	case !data.TokenId.IsNull():
+		token, err = client.GetLogShippingToken(int32(data.TokenId.ValueInt64()))
+	case !data.Name.IsNull():
+		for _, enabled := range []bool{true, false} {
+			token, err = findLogShippingTokenByName(data.Name.ValueString(), enabled, client)
+			if err != nil || token != nil {
+				break
+			}
+		}
+	default:
+		resp.Diagnostics.AddError("missing log shipping token lookup attribute",
+			fmt.Sprintf("either %s or %s must be set", logShippingTokenTokenId, logShippingTokenName))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("could not look up log shipping token", err.Error())
+		return
+	}
+
+	if token == nil {
+		resp.Diagnostics.AddError("could not look up log shipping token",
+			fmt.Sprintf("couldn't find log shipping token with name %s", data.Name.ValueString()))
+		return
+	}
+
+	data.TokenId = types.Int64Value(int64(token.Id))
+	data.Name = types.StringValue(token.Name)
+	data.Enabled = types.BoolValue(token.Enabled)
+	data.Token = types.StringValue(token.Token)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// configureEphemeral stores the provider's config in the ephemeral resource
+func configureEphemeral(req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse, config *Config) {
+	// The provider data isn't set when validating the configuration
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerConfig, ok := req.ProviderData.(Config)
+	if !ok {
+		resp.Diagnostics.AddError("unexpected provider data", fmt.Sprintf("expected Config, got %T", req.ProviderData))
+		return
+	}
+
+	*config = providerConfig
+}
@@ -0,0 +1,61 @@
+package logzio
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"logzio": func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := MuxServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		return providerServer(), nil
+	},
+}
+
+func TestAccEphemeralLogShippingToken_LookupByName(t *testing.T) {
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckApiToken(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The postcondition fails the apply if the ephemeral lookup doesn't return the managed token's id and token
+				Config: `resource "logzio_log_shipping_token" "tf_test_ephemeral" {
+  name = "tf_test_ephemeral"
+}
+
+ephemeral "logzio_log_shipping_token" "tf_test_ephemeral" {
+  name = logzio_log_shipping_token.tf_test_ephemeral.name
+
+  lifecycle {
+    postcondition {
+      condition     = self.token_id == logzio_log_shipping_token.tf_test_ephemeral.token_id && self.token == logzio_log_shipping_token.tf_test_ephemeral.token
+      error_message = "the ephemeral token doesn't match the managed token"
+    }
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logzio_log_shipping_token.tf_test_ephemeral", logShippingTokenName, "tf_test_ephemeral"),
+				),
+			},
+			{
+				Config: `ephemeral "logzio_log_shipping_token" "tf_test_ephemeral_missing" {
+  name = "tf_test_ephemeral_missing"
+}
+`,
+				ExpectError: regexp.MustCompile("couldn't find log shipping token with name tf_test_ephemeral_missing"),
+			},
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/logzio/logzio_terraform_client/metrics_accounts"
+)
+
+// ephemeralMetricsAccount looks up a metrics account's token without storing it in the plan or state
+type ephemeralMetricsAccount struct {
+	config Config
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralMetricsAccount{}
+
+func newEphemeralMetricsAccount() ephemeral.EphemeralResource {
+	return &ephemeralMetricsAccount{}
+}
+
+func (e *ephemeralMetricsAccount) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = resourceMetricsAccountType
+}
+
+func (e *ephemeralMetricsAccount) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = ephemeralAccountTokenSchema("metrics account")
+}
+
+func (e *ephemeralMetricsAccount) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	configureEphemeral(req, resp, &e.config)
+}
+
+func (e *ephemeralMetricsAccount) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralAccountTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := metrics_accounts.New(e.config.apiToken, e.config.baseUrl)
+	if err != nil {
+		resp.Diagnostics.AddError("could not create metrics accounts client", err.Error())
+		return
+	}
+
+	var account *metrics_accounts.MetricsAccount
+	switch {
+	case !data.AccountId.IsNull():
+		account, err = client.GetMetricsAccount(data.AccountId.ValueInt64())
+	case !data.AccountName.IsNull():
+		account, err = findMetricsAccountByName(client, data.AccountName.ValueString())
+	default:
+		resp.Diagnostics.AddError("missing metrics account lookup attribute",
+			fmt.Sprintf("either %s or %s must be set", metricsAccountId, metricsAccountName))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("could not look up metrics account", err.Error())
+		return
+	}
+
+	data.AccountId = types.Int64Value(int64(account.Id))
+	data.AccountName = types.StringValue(account.AccountName)
+	data.AccountToken = types.StringValue(account.AccountToken)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func findMetricsAccountByName(client *metrics_accounts.MetricsAccountClient, name string) (*metrics_accounts.MetricsAccount, error) {
+	metricsAccounts, err := client.ListMetricsAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range metricsAccounts {
+		if account.AccountName == name {
+			return &account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("couldn't find metrics account with name %s", name)
+}
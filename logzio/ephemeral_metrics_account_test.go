@@ -0,0 +1,50 @@
+package logzio
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccEphemeralMetricsAccount_LookupByName(t *testing.T) {
+	email := os.Getenv(envLogzioEmail)
+	accountName := "tf_test_ephemeral_metrics_account"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckEmail(t)
+		},
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The postcondition fails the apply if the ephemeral lookup doesn't return the managed account's id and token
+				Config: fmt.Sprintf(`resource "logzio_metrics_account" "tf_test_ephemeral" {
+  email = "%s"
+  account_name = "%s"
+  plan_uts = 100
+  authorized_accounts = []
+}
+
+ephemeral "logzio_metrics_account" "tf_test_ephemeral" {
+  account_name = logzio_metrics_account.tf_test_ephemeral.account_name
+
+  lifecycle {
+    postcondition {
+      condition     = self.account_id == logzio_metrics_account.tf_test_ephemeral.account_id && self.account_token == logzio_metrics_account.tf_test_ephemeral.account_token
+      error_message = "the ephemeral account token doesn't match the managed metrics account"
+    }
+  }
+}
+`, email, accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logzio_metrics_account.tf_test_ephemeral", metricsAccountName, accountName),
+				),
+			},
+		},
+	})
+}
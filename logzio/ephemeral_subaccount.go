@@ -0,0 +1,114 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/logzio/logzio_terraform_client/sub_accounts"
+)
+
+// ephemeralSubAccount looks up a sub account's token without storing it in the plan or state
+type ephemeralSubAccount struct {
+	config Config
+}
+
+// ephemeralAccountTokenModel is the model of the sub account and metrics account ephemeral resources
+type ephemeralAccountTokenModel struct {
+	AccountId    types.Int64  `tfsdk:"account_id"`
+	AccountName  types.String `tfsdk:"account_name"`
+	AccountToken types.String `tfsdk:"account_token"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralSubAccount{}
+
+func newEphemeralSubAccount() ephemeral.EphemeralResource {
+	return &ephemeralSubAccount{}
+}
+
+func (e *ephemeralSubAccount) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = resourceSubAccountType
+}
+
+func (e *ephemeralSubAccount) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = ephemeralAccountTokenSchema("sub account")
+}
+
+func (e *ephemeralSubAccount) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	configureEphemeral(req, resp, &e.config)
+}
+
+func (e *ephemeralSubAccount) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ephemeralAccountTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var account *sub_accounts.AccountView
+	var err error
+	switch {
+	case !data.AccountId.IsNull():
+		var detailed *sub_accounts.DetailedSubAccount
+		detailed, err = getDetailedSubAccount(e.config, data.AccountId.ValueInt64())
+		if err == nil {
+			account = &detailed.Account
+		}
+	case !data.AccountName.IsNull():
+		account, err = findDetailedSubAccountByName(e.config, data.AccountName.ValueString())
+	default:
+		resp.Diagnostics.AddError("missing sub account lookup attribute",
+			fmt.Sprintf("either %s or %s must be set", subAccountId, subAccountName))
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("could not look up sub account", err.Error())
+		return
+	}
+
+	data.AccountId = types.Int64Value(int64(account.AccountId))
+	data.AccountName = types.StringValue(account.AccountName)
+	data.AccountToken = types.StringValue(account.AccountToken)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func findDetailedSubAccountByName(m interface{}, name string) (*sub_accounts.AccountView, error) {
+	subAccounts, err := subAccountClient(m).ListDetailedSubAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subAccount := range subAccounts {
+		if subAccount.Account.AccountName == name {
+			return &subAccount.Account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("couldn't find sub-account with name %s", name)
+}
+
+// ephemeralAccountTokenSchema returns the schema of the ephemeral resources that look up an account's token
+func ephemeralAccountTokenSchema(accountType string) schema.Schema {
+	return schema.Schema{
+		Description: fmt.Sprintf("Looks up a %s's token by the account's id or name, without storing the token in the plan or state.", accountType),
+		Attributes: map[string]schema.Attribute{
+			subAccountId: schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("The %s's ID. Either account_id or account_name must be set.", accountType),
+			},
+			subAccountName: schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("The %s's name.", accountType),
+			},
+			subAccountToken: schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
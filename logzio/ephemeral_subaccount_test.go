@@ -0,0 +1,52 @@
+package logzio
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccEphemeralSubAccount_LookupByName(t *testing.T) {
+	email := os.Getenv(envLogzioEmail)
+	accountName := "tf_test_ephemeral_subaccount"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckEmail(t)
+		},
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The postcondition fails the apply if the ephemeral lookup doesn't return the managed account's id and token
+				Config: fmt.Sprintf(`resource "logzio_subaccount" "tf_test_ephemeral" {
+  deletion_protection = false
+  email = "%s"
+  account_name = "%s"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_accounts = []
+}
+
+ephemeral "logzio_subaccount" "tf_test_ephemeral" {
+  account_name = logzio_subaccount.tf_test_ephemeral.account_name
+
+  lifecycle {
+    postcondition {
+      condition     = self.account_id == logzio_subaccount.tf_test_ephemeral.account_id && self.account_token == logzio_subaccount.tf_test_ephemeral.account_token
+      error_message = "the ephemeral account token doesn't match the managed sub account"
+    }
+  }
+}
+`, email, accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logzio_subaccount.tf_test_ephemeral", subAccountName, accountName),
+				),
+			},
+		},
+	})
+}
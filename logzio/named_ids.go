@@ -0,0 +1,30 @@
+package logzio
+
+import "slices"
+
+// mergeIds appends to ids the ones from toAdd that are not already in it.
+// It's used to send the API the ids of objects referenced by name together with the ones referenced by id.
+func mergeIds[T comparable](ids []T, toAdd []T) []T {
+	merged := append(make([]T, 0, len(ids)+len(toAdd)), ids...)
+	for _, id := range toAdd {
+		if !slices.Contains(merged, id) {
+			merged = append(merged, id)
+		}
+	}
+
+	return merged
+}
+
+// removeNamedIds returns the ids read from the API without the ones of objects referenced by name.
+// Objects referenced by name are merged into the ids on create and update, and shouldn't show up as a diff in the ids attribute
+// on read. Ids that are also configured in the ids attribute are kept, since the object is referenced both ways.
+func removeNamedIds[T comparable](ids []T, namedIds []T, configuredIds []T) []T {
+	result := make([]T, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(namedIds, id) || slices.Contains(configuredIds, id) {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,31 @@
+package logzio
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNamedIds_MergeIds(t *testing.T) {
+	merged := mergeIds([]int32{5, 3}, []int32{1, 3, 4})
+	if !reflect.DeepEqual(merged, []int32{5, 3, 1, 4}) {
+		t.Errorf("unexpected merged ids: %v", merged)
+	}
+
+	if merged = mergeIds[int32](nil, nil); merged == nil || len(merged) != 0 {
+		t.Errorf("expected an empty, non nil list, got %v", merged)
+	}
+}
+
+func TestNamedIds_RemoveNamedIds(t *testing.T) {
+	if removed := removeNamedIds([]int{5, 3, 1, 4}, []int{3, 4}, nil); !reflect.DeepEqual(removed, []int{5, 1}) {
+		t.Errorf("unexpected ids after remove: %v", removed)
+	}
+
+	if removed := removeNamedIds([]int{5, 3, 1, 4}, []int{3, 4}, []int{4}); !reflect.DeepEqual(removed, []int{5, 1, 4}) {
+		t.Errorf("expected ids referenced both by id and by name to be kept, got %v", removed)
+	}
+
+	if removed := removeNamedIds([]int{3}, []int{3}, nil); removed == nil || len(removed) != 0 {
+		t.Errorf("expected an empty, non nil list, got %v", removed)
+	}
+}
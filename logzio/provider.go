@@ -13,6 +13,7 @@ const (
 	providerCustomApiUrl                  = "custom_api_url"
 	providerBaseUrl                       = "base_url"
 	providerRegion                        = "region"
+	providerAdoptExisting                 = "adopt_existing"
 	resourceAlertType                     = "logzio_alert"
 	resourceAlertV2Type                   = "logzio_alert_v2"
 	resourceEndpointType                  = "logzio_endpoint"
@@ -25,6 +26,7 @@ const (
 	resourceArchiveLogsType               = "logzio_archive_logs"
 	resourceRestoreLogsType               = "logzio_restore_logs"
 	resourceAuthenticationGroupsType      = "logzio_authentication_groups"
+	resourceAuthenticationGroupType       = "logzio_authentication_group"
 	resourceKibanaObjectType              = "logzio_kibana_object"
 	resourceS3FetcherType                 = "logzio_s3_fetcher"
 	resourceGrafanaDashboardType          = "logzio_grafana_dashboard"
@@ -34,10 +36,17 @@ const (
 	resourceGrafanaContactPointType       = "logzio_grafana_contact_point"
 	resourceMetricsRollupRulesType        = "logzio_metrics_rollup_rules"
 	resourceUnifiedAlertType              = "logzio_unified_alert"
+	dataSourceDropFilterPreviewType       = "logzio_drop_filter_preview"
+	resourceDropMetricsSetType            = "logzio_drop_metrics_set"
+	dataSourceLogShippingTokenLimitsType  = "logzio_log_shipping_token_limits"
+	dataSourceLogShippingTokensType       = "logzio_log_shipping_tokens"
+	dataSourceAccountUtilizationType      = "logzio_account_utilization"
+	resourceUsersType                     = "logzio_users"
 
-	envLogzioApiToken     = "LOGZIO_API_TOKEN"
-	envLogzioRegion       = "LOGZIO_REGION"
-	envLogzioCustomApiUrl = "LOGZIO_CUSTOM_API_URL"
+	envLogzioApiToken      = "LOGZIO_API_TOKEN"
+	envLogzioRegion        = "LOGZIO_REGION"
+	envLogzioCustomApiUrl  = "LOGZIO_CUSTOM_API_URL"
+	envLogzioAdoptExisting = "LOGZIO_ADOPT_EXISTING"
 
 	baseUrl = "https://api%s.logz.io"
 )
@@ -69,29 +78,41 @@ func ProviderWithEnvVar(apiTokenEnvVar string) *schema.Provider {
 				Description: "Custom API URL to override the default Logz.io API endpoint.",
 				DefaultFunc: schema.EnvDefaultFunc(envLogzioCustomApiUrl, ""),
 			},
+			providerAdoptExisting: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions[providerAdoptExisting],
+				DefaultFunc: schema.EnvDefaultFunc(envLogzioAdoptExisting, false),
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			resourceEndpointType:             dataSourceEndpoint(),
-			resourceUserType:                 dataSourceUser(),
-			resourceSubAccountType:           dataSourceSubAccount(),
-			resourceMetricsAccountType:       dataSourceMetricsAccount(),
-			resourceAlertV2Type:              dataSourceAlertV2(),
-			resourceLogShippingTokenType:     dataSourceLogShippingToken(),
-			resourceDropFilterType:           dataSourceDropFilter(),
-			resourceDropMetricsType:          dataSourceDropMetrics(),
-			resourceArchiveLogsType:          dataSourceArchiveLogs(),
-			resourceRestoreLogsType:          dataSourceRestoreLogs(),
-			resourceAuthenticationGroupsType: dataSourceAuthenticationGroups(),
-			resourceKibanaObjectType:         dataSourceKibanaObject(),
-			resourceS3FetcherType:            dataSourceS3Fetcher(),
-			resourceGrafanaDashboardType:     dataSourceGrafanaDashboard(),
-			resourceGrafanaFolderType:        dataSourceGrafanaFolder(),
-			resourceMetricsRollupRulesType:   dataSourceMetricsRollupRules(),
-			resourceUnifiedAlertType:         dataSourceUnifiedAlert(),
+			resourceEndpointType:                 dataSourceEndpoint(),
+			resourceUserType:                     dataSourceUser(),
+			resourceSubAccountType:               dataSourceSubAccount(),
+			resourceMetricsAccountType:           dataSourceMetricsAccount(),
+			resourceAlertV2Type:                  dataSourceAlertV2(),
+			resourceLogShippingTokenType:         dataSourceLogShippingToken(),
+			resourceDropFilterType:               dataSourceDropFilter(),
+			resourceDropMetricsType:              dataSourceDropMetrics(),
+			resourceArchiveLogsType:              dataSourceArchiveLogs(),
+			resourceRestoreLogsType:              dataSourceRestoreLogs(),
+			resourceAuthenticationGroupsType:     dataSourceAuthenticationGroups(),
+			resourceKibanaObjectType:             dataSourceKibanaObject(),
+			resourceS3FetcherType:                dataSourceS3Fetcher(),
+			resourceGrafanaDashboardType:         dataSourceGrafanaDashboard(),
+			resourceGrafanaFolderType:            dataSourceGrafanaFolder(),
+			resourceMetricsRollupRulesType:       dataSourceMetricsRollupRules(),
+			resourceUnifiedAlertType:             dataSourceUnifiedAlert(),
+			dataSourceDropFilterPreviewType:      dataSourceDropFilterPreview(),
+			dataSourceLogShippingTokenLimitsType: dataSourceLogShippingTokenLimits(),
+			dataSourceLogShippingTokensType:      dataSourceLogShippingTokens(),
+			dataSourceAccountUtilizationType:     dataSourceAccountUtilization(),
+			resourceUsersType:                    dataSourceUsers(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			resourceEndpointType:                  resourceEndpoint(),
 			resourceUserType:                      resourceUser(),
+			resourceUsersType:                     resourceUsers(),
 			resourceSubAccountType:                resourceSubAccount(),
 			resourceMetricsAccountType:            resourceMetricsAccount(),
 			resourceAlertV2Type:                   resourceAlertV2(),
@@ -101,6 +122,7 @@ func ProviderWithEnvVar(apiTokenEnvVar string) *schema.Provider {
 			resourceArchiveLogsType:               resourceArchiveLogs(),
 			resourceRestoreLogsType:               resourceRestoreLogs(),
 			resourceAuthenticationGroupsType:      resourceAuthenticationGroups(),
+			resourceAuthenticationGroupType:       resourceAuthenticationGroup(),
 			resourceKibanaObjectType:              resourceKibanaObject(),
 			resourceS3FetcherType:                 resourceS3Fetcher(),
 			resourceGrafanaDashboardType:          resourceGrafanaDashboard(),
@@ -110,6 +132,7 @@ func ProviderWithEnvVar(apiTokenEnvVar string) *schema.Provider {
 			resourceGrafanaContactPointType:       resourceGrafanaContactPoint(),
 			resourceMetricsRollupRulesType:        resourceMetricsRollupRules(),
 			resourceUnifiedAlertType:              resourceUnifiedAlert(),
+			resourceDropMetricsSetType:            resourceDropMetricsSet(),
 		},
 		ConfigureContextFunc: providerConfigureWrapper,
 	}
@@ -122,6 +145,8 @@ func init() {
 		providerApiToken:     "Your API token",
 		providerRegion:       "Your logz.io region",
 		providerCustomApiUrl: "Custom API URL to override the default Logz.io API endpoint. Useful for routing through internal gateways/proxies.",
+		providerAdoptExisting: "If true, creating a resource takes ownership of an existing object with the same natural key (name or title) instead of creating a duplicate. " +
+			"Ambiguous matches are refused. Can be overridden per resource.",
 	}
 }
 
@@ -132,24 +157,28 @@ func providerConfigure(d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	}
 	region := d.Get(providerRegion).(string)
 	customApiUrl := d.Get(providerCustomApiUrl).(string)
-	var apiUrl string
-	if customApiUrl != "" {
-		apiUrl = customApiUrl
-	} else {
-		regionCode := ""
-		if region != "" && region != "us" {
-			regionCode = fmt.Sprintf("-%s", region)
-		}
-		apiUrl = fmt.Sprintf(baseUrl, regionCode)
-	}
 
 	config := Config{
-		apiToken: apiToken.(string),
-		baseUrl:  apiUrl,
+		apiToken:      apiToken.(string),
+		baseUrl:       getApiUrl(region, customApiUrl),
+		adoptExisting: d.Get(providerAdoptExisting).(bool),
 	}
 	return config, diag.Diagnostics{}
 }
 
+// getApiUrl returns the custom api url if it's set, otherwise the api url of the region
+func getApiUrl(region string, customApiUrl string) string {
+	if customApiUrl != "" {
+		return customApiUrl
+	}
+
+	regionCode := ""
+	if region != "" && region != "us" {
+		regionCode = fmt.Sprintf("-%s", region)
+	}
+	return fmt.Sprintf(baseUrl, regionCode)
+}
+
 func providerConfigureWrapper(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return providerConfigure(d)
 }
@@ -0,0 +1,137 @@
+package logzio
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+const providerTypeName = "logzio"
+
+// frameworkProvider serves the ephemeral resources, which the SDK provider doesn't support.
+// It's muxed with the SDK provider, so both must have the same provider schema and configuration.
+type frameworkProvider struct {
+	apiTokenEnvVar string
+}
+
+type frameworkProviderModel struct {
+	ApiToken      types.String `tfsdk:"api_token"`
+	Region        types.String `tfsdk:"region"`
+	CustomApiUrl  types.String `tfsdk:"custom_api_url"`
+	AdoptExisting types.Bool   `tfsdk:"adopt_existing"`
+}
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+// MuxServer returns a server for both the SDK provider and the framework provider
+func MuxServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return MuxServerWithEnvVar(ctx, envLogzioApiToken)
+}
+
+// MuxServerWithEnvVar is MuxServer, with the api token read from apiTokenEnvVar
+func MuxServerWithEnvVar(ctx context.Context, apiTokenEnvVar string) (func() tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		ProviderWithEnvVar(apiTokenEnvVar).GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{apiTokenEnvVar: apiTokenEnvVar}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = providerTypeName
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	// The SDK makes a required attribute optional when its DefaultFunc has a value
+	apiTokenFromEnv := os.Getenv(p.apiTokenEnvVar) != ""
+
+	// Must match the schema of the SDK provider, see ProviderWithEnvVar
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			providerApiToken: schema.StringAttribute{
+				Required:    !apiTokenFromEnv,
+				Optional:    apiTokenFromEnv,
+				Sensitive:   true,
+				Description: descriptions[providerApiToken],
+			},
+			providerRegion: schema.StringAttribute{
+				Optional:    true,
+				Description: descriptions[providerRegion],
+			},
+			providerCustomApiUrl: schema.StringAttribute{
+				Optional:    true,
+				Description: "Custom API URL to override the default Logz.io API endpoint.",
+			},
+			providerAdoptExisting: schema.BoolAttribute{
+				Optional:    true,
+				Description: descriptions[providerAdoptExisting],
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Same defaults as the SDK provider's EnvDefaultFunc
+	apiToken := stringValueOrEnv(data.ApiToken, p.apiTokenEnvVar)
+	if apiToken == "" {
+		resp.Diagnostics.AddError("missing api token",
+			"can't find the "+providerApiToken+", either set it in the provider or set the "+envLogzioApiToken+" env var")
+		return
+	}
+
+	adoptExisting := data.AdoptExisting.ValueBool()
+	if data.AdoptExisting.IsNull() {
+		adoptExisting, _ = strconv.ParseBool(os.Getenv(envLogzioAdoptExisting))
+	}
+
+	config := Config{
+		apiToken:      apiToken,
+		baseUrl:       getApiUrl(stringValueOrEnv(data.Region, envLogzioRegion), stringValueOrEnv(data.CustomApiUrl, envLogzioCustomApiUrl)),
+		adoptExisting: adoptExisting,
+	}
+	resp.EphemeralResourceData = config
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newEphemeralLogShippingToken,
+		newEphemeralSubAccount,
+		newEphemeralMetricsAccount,
+	}
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func stringValueOrEnv(value types.String, envVar string) string {
+	if value.IsNull() {
+		return os.Getenv(envVar)
+	}
+
+	return value.ValueString()
+}
@@ -0,0 +1,49 @@
+package logzio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestProviderFramework_MuxServer(t *testing.T) {
+	providerServer, err := MuxServer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The mux server fails the schema request if the providers' schemas are different
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	sensitiveAttributes := map[string]string{
+		resourceLogShippingTokenType: logShippingTokenToken,
+		resourceSubAccountType:       subAccountToken,
+		resourceMetricsAccountType:   metricsAccountToken,
+	}
+	for typeName, attributeName := range sensitiveAttributes {
+		ephemeralSchema, ok := resp.EphemeralResourceSchemas[typeName]
+		if !ok {
+			t.Errorf("expected ephemeral resource %s", typeName)
+			continue
+		}
+
+		for _, attribute := range ephemeralSchema.Block.Attributes {
+			if attribute.Name == attributeName && !attribute.Sensitive {
+				t.Errorf("expected %s.%s to be sensitive", typeName, attributeName)
+			}
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas[resourceLogShippingTokenType]; !ok {
+		t.Errorf("expected the SDK provider's resources to be served")
+	}
+}
@@ -142,6 +142,14 @@ func testAccPreCheckEnv(t *testing.T, env string) {
 	}
 }
 
+// testAccConfig returns the provider config from the same env vars as the provider, for tests that call the API directly
+func testAccConfig() Config {
+	return Config{
+		apiToken: os.Getenv(envLogzioApiToken),
+		baseUrl:  getApiUrl(os.Getenv(envLogzioRegion), os.Getenv(envLogzioCustomApiUrl)),
+	}
+}
+
 func testAccPreCheckApiToken(t *testing.T) {
 	testAccPreCheckEnv(t, envLogzioApiToken)
 }
@@ -25,6 +25,7 @@ const (
 	alertV2IsEnabled                   string = "is_enabled"
 	alertV2NotificationEmails          string = "notification_emails"
 	alertV2NotificationEndpoints       string = "alert_notification_endpoints"
+	alertV2NotificationEndpointNames   string = "alert_notification_endpoint_names"
 	alertV2SuppressNotificationMinutes string = "suppress_notifications_minutes"
 	alertV2OutputType                  string = "output_type"
 	alertV2QueryString                 string = "query_string"
@@ -115,6 +116,13 @@ func resourceAlertV2() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
+			alertV2NotificationEndpointNames: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			alertV2SuppressNotificationMinutes: {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -263,7 +271,10 @@ func resourceAlertV2() *schema.Resource {
 
 // resourceAlertV2Create creates a new alert (v2) in logzio
 func resourceAlertV2Create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	createAlert := createCreateAlertType(d)
+	createAlert, err := withAlertV2EndpointNames(m, d, createCreateAlertType(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	jsonBytes, err := json.Marshal(createAlert)
 	tflog.Debug(ctx, fmt.Sprintf("%s::%s", "resourceAlertCreate", string(jsonBytes)))
@@ -306,6 +317,17 @@ func resourceAlertV2Read(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 	}
 
+	endpointNames := utils.ParseInterfaceSliceToStringSlice(d.Get(alertV2NotificationEndpointNames).(*schema.Set).List())
+	if len(endpointNames) > 0 {
+		namedEndpointIds, err := endpointIdsByTitles(m, endpointNames)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("could not resolve %s: %v", alertV2NotificationEndpointNames, err))
+		} else {
+			configuredIds := interfaceSliceToIntSlice(d.Get(alertV2NotificationEndpoints).(*schema.Set).List())
+			alert.Output.Recipients.NotificationEndpointIds = removeNamedIds(alert.Output.Recipients.NotificationEndpointIds, namedEndpointIds, configuredIds)
+		}
+	}
+
 	setValuesAlertV2(d, alert)
 	setCreatedUpdatedFields(d, alert)
 
@@ -316,8 +338,12 @@ func resourceAlertV2Read(ctx context.Context, d *schema.ResourceData, m interfac
 func resourceAlertV2Update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	alertId, _ := utils.IdFromResourceData(d)
 	updateAlert := createCreateAlertType(d)
+	updateAlertWithEndpointNames, err := withAlertV2EndpointNames(m, d, updateAlert)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	jsonBytes, err := json.Marshal(updateAlert)
+	jsonBytes, err := json.Marshal(updateAlertWithEndpointNames)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -326,7 +352,7 @@ func resourceAlertV2Update(ctx context.Context, d *schema.ResourceData, m interf
 	tflog.Debug(ctx, fmt.Sprintf("%s::%s", "resourceAlertCreate", jsonStr))
 
 	client := alertV2Client(m)
-	_, err = client.UpdateAlert(alertId, updateAlert)
+	_, err = client.UpdateAlert(alertId, updateAlertWithEndpointNames)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "valueAggregationTypeComposite") {
@@ -615,6 +641,19 @@ func createCreateAlertType(d *schema.ResourceData) alerts_v2.CreateAlertType {
 	return createAlert
 }
 
+// withAlertV2EndpointNames adds to the alert recipients the ids of the notification endpoints referenced by name
+func withAlertV2EndpointNames(m interface{}, d *schema.ResourceData, alert alerts_v2.CreateAlertType) (alerts_v2.CreateAlertType, error) {
+	endpointNames := utils.ParseInterfaceSliceToStringSlice(d.Get(alertV2NotificationEndpointNames).(*schema.Set).List())
+	namedEndpointIds, err := endpointIdsByTitles(m, endpointNames)
+	if err != nil {
+		return alert, fmt.Errorf("could not resolve %s: %v", alertV2NotificationEndpointNames, err)
+	}
+
+	ids := append([]int{}, alert.Output.Recipients.NotificationEndpointIds...)
+	alert.Output.Recipients.NotificationEndpointIds = mergeIds(ids, namedEndpointIds)
+	return alert, nil
+}
+
 func getScheduleFromSchema(d *schema.ResourceData) alerts_v2.ScheduleObj {
 	cronExpression := d.Get(alertV2ScheduleCronExpression).(string)
 	timezone := d.Get(alertV2ScheduleTimezone).(string)
@@ -144,6 +144,74 @@ func TestAccLogzioAlertV2_ScheduleTests(t *testing.T) {
 	})
 }
 
+func TestAccLogzioAlertV2_EndpointNames(t *testing.T) {
+	endpointTitle := "tf-alert-v2-endpoint-" + getRandomId()
+	resourceName := "logzio_alert_v2.test_alert_v2_endpoint_names"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getAlertV2EndpointNamesConfig(endpointTitle, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, alertV2NotificationEndpointNames+".#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, alertV2NotificationEndpointNames+".*", endpointTitle),
+					resource.TestCheckResourceAttr(resourceName, alertV2NotificationEndpoints+".#", "0"),
+				),
+			},
+			{
+				// The endpoint is referenced both by id and by name, and should stay in the ids attribute
+				Config: getAlertV2EndpointNamesConfig(endpointTitle, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, alertV2NotificationEndpointNames+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, alertV2NotificationEndpoints+".#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, alertV2NotificationEndpoints+".*", "logzio_endpoint.test_alert_v2_endpoint", "endpoint_id"),
+				),
+			},
+		},
+	})
+}
+
+func getAlertV2EndpointNamesConfig(endpointTitle string, referenceById bool) string {
+	endpointIds := "[]"
+	if referenceById {
+		endpointIds = "[logzio_endpoint.test_alert_v2_endpoint.endpoint_id]"
+	}
+
+	return fmt.Sprintf(`
+resource "logzio_endpoint" "test_alert_v2_endpoint" {
+  title         = "%s"
+  endpoint_type = "slack"
+  description   = "endpoint referenced by name from an alert"
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/1"
+  }
+}
+
+resource "logzio_alert_v2" "test_alert_v2_endpoint_names" {
+  title                             = "Test Alert V2 Endpoint Names"
+  search_timeframe_minutes          = 5
+  is_enabled                        = false
+  alert_notification_endpoints      = %s
+  alert_notification_endpoint_names = [logzio_endpoint.test_alert_v2_endpoint.title]
+  output_type                       = "JSON"
+  sub_components {
+    query_string                 = "loglevel:ERROR"
+    should_query_on_all_accounts = true
+    operation                    = "GREATER_THAN"
+    value_aggregation_type       = "COUNT"
+    severity_threshold_tiers {
+      severity  = "HIGH"
+      threshold = 10
+    }
+  }
+}
+`, endpointTitle, endpointIds)
+}
+
 func resourceTestAlertV2(name string, path string) string {
 	content, err := os.ReadFile(fmt.Sprintf("testdata/fixtures/%s.tf", path))
 	if err != nil {
@@ -0,0 +1,231 @@
+package logzio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/avast/retry-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logzio/logzio_terraform_client/authentication_groups"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+// errAuthGroupsConflict is returned when a write to the authentication groups was overwritten by a concurrent write
+var errAuthGroupsConflict = errors.New("authentication groups were changed concurrently")
+
+// resourceAuthenticationGroup manages a single authentication group, keyed by the group name.
+// The Logz.io API only reads and replaces the full list of groups, so every change reads the list,
+// modifies this group's entry and writes the list back.
+func resourceAuthenticationGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAuthenticationGroupCreate,
+		ReadContext:   resourceAuthenticationGroupRead,
+		UpdateContext: resourceAuthenticationGroupUpdate,
+		DeleteContext: resourceAuthenticationGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			authGroupGroup: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: utils.ValidateGroupName,
+			},
+			authGroupUserRole: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: utils.ValidateUserRole,
+			},
+		},
+	}
+}
+
+func resourceAuthenticationGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	group := getAuthenticationGroupFromSchema(d)
+	isRetry := false
+	err := modifyAuthenticationGroups(ctx, m,
+		func(groups []authentication_groups.AuthenticationGroup) ([]authentication_groups.AuthenticationGroup, error) {
+			if existing, found := findAuthenticationGroup(groups, group.Group); found {
+				// On a retry, the group might be there from our previous write, with a role that was changed concurrently
+				if !isRetry {
+					return nil, fmt.Errorf("authentication group %s already exists, import it to manage it with terraform", group.Group)
+				}
+
+				return replaceAuthenticationGroup(groups, existing.Group, group), nil
+			}
+
+			isRetry = true
+			return append(groups, group), nil
+		},
+		func(groups []authentication_groups.AuthenticationGroup) bool {
+			existing, found := findAuthenticationGroup(groups, group.Group)
+			return found && existing.UserRole == group.UserRole
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(group.Group)
+	return resourceAuthenticationGroupRead(ctx, d, m)
+}
+
+func resourceAuthenticationGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groups, err := getAllAuthenticationGroups(authenticationGroupsClient(m))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, found := findAuthenticationGroup(groups, d.Id())
+	if !found {
+		// If we were not able to find the resource - delete from state
+		tflog.Error(ctx, "could not find authentication group "+d.Id())
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	d.Set(authGroupGroup, group.Group)
+	d.Set(authGroupUserRole, group.UserRole)
+	return nil
+}
+
+func resourceAuthenticationGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	group := getAuthenticationGroupFromSchema(d)
+	err := modifyAuthenticationGroups(ctx, m,
+		func(groups []authentication_groups.AuthenticationGroup) ([]authentication_groups.AuthenticationGroup, error) {
+			if _, found := findAuthenticationGroup(groups, group.Group); !found {
+				return nil, fmt.Errorf("authentication group %s was deleted outside of terraform", group.Group)
+			}
+
+			return replaceAuthenticationGroup(groups, group.Group, group), nil
+		},
+		func(groups []authentication_groups.AuthenticationGroup) bool {
+			existing, found := findAuthenticationGroup(groups, group.Group)
+			return found && existing.UserRole == group.UserRole
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAuthenticationGroupRead(ctx, d, m)
+}
+
+func resourceAuthenticationGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	groupName := d.Id()
+	err := modifyAuthenticationGroups(ctx, m,
+		func(groups []authentication_groups.AuthenticationGroup) ([]authentication_groups.AuthenticationGroup, error) {
+			remaining := make([]authentication_groups.AuthenticationGroup, 0, len(groups))
+			for _, group := range groups {
+				if group.Group != groupName {
+					remaining = append(remaining, group)
+				}
+			}
+
+			return remaining, nil
+		},
+		func(groups []authentication_groups.AuthenticationGroup) bool {
+			_, found := findAuthenticationGroup(groups, groupName)
+			return !found
+		})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// modifyAuthenticationGroups reads the full list of authentication groups, modifies it and writes it back.
+// Since the API replaces the whole list, a concurrent write can overwrite the change. The list is read again after the write,
+// and if isApplied doesn't find the change, the read-modify-write is retried on top of the current list.
+func modifyAuthenticationGroups(ctx context.Context, m interface{},
+	modify func([]authentication_groups.AuthenticationGroup) ([]authentication_groups.AuthenticationGroup, error),
+	isApplied func([]authentication_groups.AuthenticationGroup) bool) error {
+	client := authenticationGroupsClient(m)
+	return retry.Do(
+		func() error {
+			groups, err := getAllAuthenticationGroups(client)
+			if err != nil {
+				return err
+			}
+
+			updatedGroups, err := modify(groups)
+			if err != nil {
+				return err
+			}
+
+			_, err = client.PostAuthenticationGroups(updatedGroups)
+			if err != nil {
+				return err
+			}
+
+			groups, err = getAllAuthenticationGroups(client)
+			if err != nil {
+				return err
+			}
+
+			if !isApplied(groups) {
+				tflog.Warn(ctx, "authentication groups change was not applied, it might have been overwritten by a concurrent change. Retrying")
+				return errAuthGroupsConflict
+			}
+
+			return nil
+		},
+		retry.RetryIf(
+			// Retry ONLY if the change was overwritten
+			func(err error) bool {
+				return errors.Is(err, errAuthGroupsConflict)
+			}),
+		retry.DelayType(retry.BackOffDelay),
+		retry.Attempts(authGroupRetryAttempts),
+		retry.LastErrorOnly(true),
+	)
+}
+
+// getAllAuthenticationGroups returns the account's authentication groups, or an empty list if there are none
+func getAllAuthenticationGroups(client *authentication_groups.AuthenticationGroupsClient) ([]authentication_groups.AuthenticationGroup, error) {
+	groups, err := client.GetAuthenticationGroups()
+	if err != nil {
+		if strings.Contains(err.Error(), "missing authentication groups") {
+			return []authentication_groups.AuthenticationGroup{}, nil
+		}
+
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func findAuthenticationGroup(groups []authentication_groups.AuthenticationGroup, groupName string) (authentication_groups.AuthenticationGroup, bool) {
+	for _, group := range groups {
+		if group.Group == groupName {
+			return group, true
+		}
+	}
+
+	return authentication_groups.AuthenticationGroup{}, false
+}
+
+// replaceAuthenticationGroup returns the groups with the entry of groupName replaced by group
+func replaceAuthenticationGroup(groups []authentication_groups.AuthenticationGroup, groupName string, group authentication_groups.AuthenticationGroup) []authentication_groups.AuthenticationGroup {
+	replaced := make([]authentication_groups.AuthenticationGroup, 0, len(groups))
+	for _, existing := range groups {
+		if existing.Group == groupName {
+			existing = group
+		}
+		replaced = append(replaced, existing)
+	}
+
+	return replaced
+}
+
+func getAuthenticationGroupFromSchema(d *schema.ResourceData) authentication_groups.AuthenticationGroup {
+	return authentication_groups.AuthenticationGroup{
+		Group:    d.Get(authGroupGroup).(string),
+		UserRole: d.Get(authGroupUserRole).(string),
+	}
+}
@@ -0,0 +1,63 @@
+package logzio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_client/authentication_groups"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccLogzioAuthenticationGroup_AuthenticationGroup(t *testing.T) {
+	firstResourceName := resourceAuthenticationGroupType + ".tf_group_1"
+	secondResourceName := resourceAuthenticationGroupType + ".tf_group_2"
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Create
+				Config: getAuthGroupConfig(authentication_groups.AuthGroupsUserRoleReadonly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(firstResourceName, "id", "tf_single_group_testing_1"),
+					resource.TestCheckResourceAttr(firstResourceName, authGroupUserRole, authentication_groups.AuthGroupsUserRoleReadonly),
+					resource.TestCheckResourceAttr(secondResourceName, "id", "tf_single_group_testing_2"),
+					resource.TestCheckResourceAttr(secondResourceName, authGroupUserRole, authentication_groups.AuthGroupsUserRoleRegular),
+				),
+			},
+			{
+				// Update - change role of one group only
+				Config: getAuthGroupConfig(authentication_groups.AuthGroupsUserRoleAdmin),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(firstResourceName, authGroupUserRole, authentication_groups.AuthGroupsUserRoleAdmin),
+					resource.TestCheckResourceAttr(secondResourceName, authGroupUserRole, authentication_groups.AuthGroupsUserRoleRegular),
+				),
+			},
+			{
+				// Import by group name
+				Config:            getAuthGroupConfig(authentication_groups.AuthGroupsUserRoleAdmin),
+				ResourceName:      firstResourceName,
+				ImportState:       true,
+				ImportStateId:     "tf_single_group_testing_1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func getAuthGroupConfig(firstGroupRole string) string {
+	return fmt.Sprintf(`resource "logzio_authentication_group" "tf_group_1" {
+	group = "tf_single_group_testing_1"
+	user_role = "%s"
+}
+
+resource "logzio_authentication_group" "tf_group_2" {
+	group = "tf_single_group_testing_2"
+	user_role = "USER_ROLE_REGULAR"
+}
+`, firstGroupRole)
+}
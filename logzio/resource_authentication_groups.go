@@ -9,10 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/logzio/logzio_terraform_client/authentication_groups"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
-	"math/rand"
-	"strconv"
 	"strings"
-	"time"
 )
 
 const (
@@ -22,6 +19,9 @@ const (
 	authGroupUserRole   = "user_role"
 
 	authGroupRetryAttempts = 8
+
+	// The resource manages all the account's authentication groups, so it has a single, fixed id
+	authGroupsResourceId = "authentication_groups"
 )
 
 func resourceAuthenticationGroups() *schema.Resource {
@@ -29,7 +29,7 @@ func resourceAuthenticationGroups() *schema.Resource {
 		CreateContext: resourceAuthenticationGroupsCreate,
 		ReadContext:   resourceAuthenticationGroupsRead,
 		UpdateContext: resourceAuthenticationGroupsUpdate,
-		DeleteContext: resourceAuthenticationGroupDelete,
+		DeleteContext: resourceAuthenticationGroupsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -75,15 +75,15 @@ func resourceAuthenticationGroupsCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	// Logz.io authentication groups API doesn't return id, we need to create a random id for TF.
-	rand.Seed(time.Now().UnixNano())
-	id := rand.Int()
-	d.SetId(strconv.FormatInt(int64(id), 10))
+	// Logz.io authentication groups API doesn't return id
+	d.SetId(authGroupsResourceId)
 
 	return resourceAuthenticationGroupsRead(ctx, d, m)
 }
 
 func resourceAuthenticationGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Resources created or imported with an older version have a random id
+	d.SetId(authGroupsResourceId)
 	id := d.Id()
 	groups, err := authenticationGroupsClient(m).GetAuthenticationGroups()
 	if err != nil {
@@ -150,7 +150,7 @@ func resourceAuthenticationGroupsUpdate(ctx context.Context, d *schema.ResourceD
 	return nil
 }
 
-func resourceAuthenticationGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceAuthenticationGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	_, err := authenticationGroupsClient(m).PostAuthenticationGroups([]authentication_groups.AuthenticationGroup{})
 
 	if err != nil {
@@ -35,7 +35,7 @@ func TestAccLogzioAuthenticationGroups_AuthenticationGroups(t *testing.T) {
 				// Create
 				Config: getAuthGroupsConfig(resourceName, userRolesInConfigCreate),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttrSet(fullResourceName, authGroupsId),
+					resource.TestCheckResourceAttr(fullResourceName, authGroupsId, authGroupsResourceId),
 					resource.TestCheckResourceAttr(fullResourceName, authGroupsAuthGroup+".#", "3"),
 					testAccCheckAuthGroups(fullResourceName, userRolesInConfigCreate),
 				),
@@ -6,9 +6,12 @@ import (
 	"github.com/avast/retry-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/logzio/logzio_terraform_client/drop_filters"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+	"net/http"
+	"strings"
 )
 
 const (
@@ -39,6 +42,10 @@ func resourceDropFilter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		// Changing these fields replaces the drop filter in the API, so it will get a new id
+		CustomizeDiff: customdiff.ComputedIf(dropFilterIdField, func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+			return d.HasChanges(dropFilterLogType, dropFilterFieldConditions, dropFilterThresholdInGB)
+		}),
 		Schema: map[string]*schema.Schema{
 			dropFilterIdField: {
 				Type:     schema.TypeString,
@@ -53,23 +60,19 @@ func resourceDropFilter() *schema.Resource {
 			dropFilterLogType: {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			dropFilterFieldConditions: {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						dropFilterFieldName: {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 						dropFilterValue: {
 							Type:     schema.TypeString,
 							Required: true,
-							ForceNew: true,
 						},
 					},
 				},
@@ -77,7 +80,6 @@ func resourceDropFilter() *schema.Resource {
 			dropFilterThresholdInGB: {
 				Type:     schema.TypeFloat,
 				Optional: true,
-				ForceNew: true,
 			},
 		},
 	}
@@ -119,8 +121,15 @@ func resourceDropFilterRead(ctx context.Context, d *schema.ResourceData, m inter
 	return nil
 }
 
-// resourceDropFilterUpdate updates drop field by id - activate or deactivate
+// resourceDropFilterUpdate updates drop field by id - activate or deactivate.
+// Changes to the log type, conditions or threshold replace the drop filter (see replaceDropFilter).
 func resourceDropFilterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges(dropFilterLogType, dropFilterFieldConditions, dropFilterThresholdInGB) {
+		if err := replaceDropFilter(ctx, d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	activate := d.Get(dropFilterActive).(bool)
 	var err error
 	if activate {
@@ -179,6 +188,101 @@ func resourceDropFilterDelete(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
+// replaceDropFilter applies changes the API can't update in place, with a create-before-destroy swap:
+// a drop filter with the new configuration is created, and the old one is deleted only after the new one shows on read,
+// so logs matching the old filter keep being dropped during the update.
+// The API doesn't allow two drop filters with the same log type and field conditions, so when only the threshold changed
+// and the new drop filter is rejected as a duplicate, the old one is deleted before the new one is created.
+// Any other create error leaves the old drop filter unchanged.
+func replaceDropFilter(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	oldId := d.Id()
+	oldDeleted := false
+	client := dropFilterClient(m)
+	newDropFilter, err := client.CreateDropFilter(createCreatDropFilterFromSchema(d))
+	if err != nil {
+		if d.HasChanges(dropFilterLogType, dropFilterFieldConditions) || !isDuplicateDropFilterError(err) {
+			return fmt.Errorf("could not create drop filter with the updated configuration, drop filter %s was not changed: %v", oldId, err)
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("could not create drop filter with the updated threshold next to drop filter %s, deleting it first: %v", oldId, err))
+		if err = client.DeleteDropFilter(oldId); err != nil {
+			return fmt.Errorf("could not delete drop filter %s to update its threshold: %v", oldId, err)
+		}
+
+		oldDeleted = true
+		newDropFilter, err = client.CreateDropFilter(createCreatDropFilterFromSchema(d))
+		if err != nil {
+			d.SetId("")
+			return fmt.Errorf("drop filter %s was deleted to update its threshold, but the drop filter with the updated threshold could not be created: %v", oldId, err)
+		}
+
+		d.SetId(newDropFilter.Id)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("created drop filter %s to replace drop filter %s", newDropFilter.Id, oldId))
+	err = confirmReplacementDropFilter(client, newDropFilter.Id, d.Get(dropFilterActive).(bool))
+	if oldDeleted {
+		if err != nil {
+			return fmt.Errorf("drop filter %s replaced drop filter %s, but could not be confirmed: %v", newDropFilter.Id, oldId, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		if deleteErr := client.DeleteDropFilter(newDropFilter.Id); deleteErr != nil {
+			tflog.Error(ctx, fmt.Sprintf("could not delete unconfirmed drop filter %s: %v", newDropFilter.Id, deleteErr))
+		}
+
+		return fmt.Errorf("could not confirm drop filter %s with the updated configuration, drop filter %s was not changed: %v", newDropFilter.Id, oldId, err)
+	}
+
+	d.SetId(newDropFilter.Id)
+	if err = client.DeleteDropFilter(oldId); err != nil {
+		return fmt.Errorf("drop filter %s replaced drop filter %s, but the old drop filter could not be deleted and should be deleted manually: %v", newDropFilter.Id, oldId, err)
+	}
+
+	return nil
+}
+
+// isDuplicateDropFilterError returns true if the API rejected a drop filter because one with the same log type and field conditions exists
+func isDuplicateDropFilterError(err error) bool {
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, fmt.Sprintf("status code %d", http.StatusConflict)) {
+		return true
+	}
+
+	return strings.Contains(message, fmt.Sprintf("status code %d", http.StatusBadRequest)) &&
+		(strings.Contains(message, "already exist") || strings.Contains(message, "duplicate"))
+}
+
+// confirmReplacementDropFilter waits until the replacement drop filter shows on read.
+// Drop filters are always created active, so an inactive one is deactivated first, before it drops any logs the old one didn't.
+func confirmReplacementDropFilter(client *drop_filters.DropFiltersClient, dropFilterId string, active bool) error {
+	if !active {
+		if _, err := client.DeactivateDropFilter(dropFilterId); err != nil {
+			return err
+		}
+	}
+
+	return retry.Do(
+		func() error {
+			dropFilters, err := client.RetrieveDropFilters()
+			if err != nil {
+				return err
+			}
+
+			if findDropFilterById(dropFilterId, dropFilters) == nil {
+				return fmt.Errorf("drop filter %s not found", dropFilterId)
+			}
+
+			return nil
+		},
+		retry.DelayType(retry.BackOffDelay),
+		retry.Attempts(dropFilterRetryAttempts),
+	)
+}
+
 func setDropFilter(d *schema.ResourceData, dropFilter *drop_filters.DropFilter) {
 	d.Set(dropFilterIdField, dropFilter.Id)
 	d.Set(dropFilterActive, dropFilter.Active)
@@ -1,6 +1,7 @@
 package logzio
 
 import (
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
@@ -20,6 +21,9 @@ const (
 	dropFilterResourceUpdateDropFilter                  = "update_drop_filter"
 	dropFilterResourceUpdateDropFilterChangeLogType     = "update_drop_filter_change_log_type"
 	dropFilterResourceUpdateDropFilterRemoveLogType     = "update_drop_filter_remove_log_type"
+	dropFilterResourceUpdateDropFilterConditions        = "update_drop_filter_conditions_and_threshold"
+	dropFilterResourceUpdateDropFilterThreshold         = "update_drop_filter_threshold"
+	dropFilterResourceUpdateDropFilterInactive          = "update_drop_filter_conditions_inactive"
 )
 
 func TestAccLogzioDropFilter_CreateDropFilter(t *testing.T) {
@@ -159,6 +163,52 @@ func TestAccLogzioDropFilter_UpdateDropFilterRemoveLogType(t *testing.T) {
 	})
 }
 
+func TestAccLogzioDropFilter_UpdateDropFilterConditionsAndThreshold(t *testing.T) {
+	filterName := "test_update_drop_filter_conditions_and_threshold"
+	resourceName := "logzio_drop_filter." + filterName
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: resourceTestDropFilter(filterName, dropFilterResourceCreateDropFilterWithGbThreshold),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropFilterFieldConditions+".#", "2"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterThresholdInGB, "50"),
+				),
+			},
+			{
+				// Only the threshold changes, so the new drop filter has the same log type and conditions as the old one
+				Config: resourceTestDropFilter(filterName, dropFilterResourceUpdateDropFilterThreshold),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropFilterFieldConditions+".#", "2"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterThresholdInGB, "75"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterActive, "true"),
+				),
+			},
+			{
+				Config: resourceTestDropFilter(filterName, dropFilterResourceUpdateDropFilterConditions),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropFilterLogType, "some_type_create"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterFieldConditions+".#", "1"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterFieldConditions+".0."+dropFilterValue, "some_updated_value"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterThresholdInGB, "100"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterActive, "true"),
+				),
+			},
+			{
+				Config: resourceTestDropFilter(filterName, dropFilterResourceUpdateDropFilterInactive),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropFilterFieldConditions+".0."+dropFilterValue, "some_inactive_value"),
+					resource.TestCheckResourceAttr(resourceName, dropFilterActive, "false"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccLogzioDropFilter_CreateDropFilterNoFieldConditions(t *testing.T) {
 	filterName := "test_create_drop_filter_no_field_conditions"
 	defer utils.SleepAfterTest()
@@ -207,6 +257,28 @@ func TestAccLogzioDropFilter_CreateDropFilterNoValue(t *testing.T) {
 	})
 }
 
+func TestDropFilter_IsDuplicateDropFilterError(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"conflict":              {err: errors.New("API call CreateDropFilter failed with status code 409, data: {}"), expected: true},
+		"bad request duplicate": {err: errors.New(`API call CreateDropFilter failed with status code 400, data: {"message":"Drop filter already exists"}`), expected: true},
+		"bad request other":     {err: errors.New(`API call CreateDropFilter failed with status code 400, data: {"message":"invalid field"}`)},
+		"server error":          {err: errors.New("API call CreateDropFilter failed with status code 500, data: duplicate")},
+		"unauthorized":          {err: errors.New("API call CreateDropFilter failed with status code 401, data: {}")},
+		"network error":         {err: errors.New("dial tcp: connection refused")},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if isDuplicateDropFilterError(tc.err) != tc.expected {
+				t.Errorf("expected %t for error %v", tc.expected, tc.err)
+			}
+		})
+	}
+}
+
 func resourceTestDropFilter(name string, path string) string {
 	content, err := os.ReadFile(fmt.Sprintf("testdata/fixtures/%s.tf", path))
 	if err != nil {
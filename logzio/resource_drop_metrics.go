@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/avast/retry-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -32,6 +33,9 @@ const (
 	dropMetricsModifiedBy           = "modified_by"
 
 	dropMetricsRetryAttempts = 8
+
+	dropMetricsImportIdSeparator     = ":"
+	errorMultipleMatchingDropMetrics = "found multiple (%d) metrics drop filters matching the criteria, please specify an id or add more search criteria (ids: %v)"
 )
 
 // Returns the drop metrics client with the api token from the provider
@@ -70,7 +74,7 @@ func resourceDropMetrics() *schema.Resource {
 		UpdateContext: resourceDropMetricsUpdate,
 		DeleteContext: resourceDropMetricsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDropMetricsImport,
 		},
 		Schema: map[string]*schema.Schema{
 			dropMetricsIdField: {
@@ -134,12 +138,25 @@ func resourceDropMetrics() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			adoptExisting: adoptExistingSchema(),
 		},
 	}
 }
 
 // resourceDropMetricsCreate creates a new metrics drop filter in logzio
 func resourceDropMetricsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if shouldAdoptExisting(d, m) {
+		id, err := findDropMetricsIdToAdopt(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if id != "" {
+			d.SetId(id)
+			return resourceDropMetricsUpdate(ctx, d, m)
+		}
+	}
+
 	createDropMetrics := createCreateUpdateDropMetricsFromSchema(d)
 
 	// dropFilter, err := dropFilterClient(m).CreateDropFilter(createDropFilter)
@@ -210,6 +227,69 @@ func resourceDropMetricsDelete(ctx context.Context, d *schema.ResourceData, m in
 	return nil
 }
 
+// findDropMetricsIdToAdopt searches the account for a metrics drop filter with the same name.
+// Drop filters without a name have no natural key, so they are never adopted.
+func findDropMetricsIdToAdopt(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
+	name := d.Get(dropMetricsName).(string)
+	if name == "" {
+		tflog.Info(ctx, fmt.Sprintf("%s is not set, a metrics drop filter can't be adopted without a name", dropMetricsName))
+		return "", nil
+	}
+
+	matchingIds, err := searchDropMetricsIdsByName(dropMetricsClient(m), int64Attr(d, dropMetricsAccountId), name)
+	if err != nil {
+		return "", err
+	}
+
+	return findIdToAdopt(ctx, "metrics drop filter", dropMetricsName, name, matchingIds)
+}
+
+// searchDropMetricsIdsByName returns the ids of the account's metrics drop filters with the given name
+func searchDropMetricsIdsByName(client *drop_metrics.DropMetricsClient, accountId int64, name string) ([]string, error) {
+	existing, err := searchDropMetricsSetScope(client, accountId, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingIds []string
+	for _, dropMetric := range existing {
+		if dropMetric.Name == name {
+			matchingIds = append(matchingIds, int64ToStr(dropMetric.Id))
+		}
+	}
+
+	return matchingIds, nil
+}
+
+// resourceDropMetricsImport imports a metrics drop filter by its id, or by account_id:name
+func resourceDropMetricsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	accountIdStr, name, found := strings.Cut(d.Id(), dropMetricsImportIdSeparator)
+	if !found {
+		return schema.ImportStatePassthroughContext(ctx, d, m)
+	}
+
+	accountId, err := strconv.ParseInt(accountIdStr, 10, 64)
+	if err != nil || name == "" {
+		return nil, fmt.Errorf("invalid import id %s, expected id or account_id:name", d.Id())
+	}
+
+	matchingIds, err := searchDropMetricsIdsByName(dropMetricsClient(m), accountId, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matchingIds) {
+	case 0:
+		return nil, fmt.Errorf("couldn't find metrics drop filter with name %s in account %d", name, accountId)
+	case 1:
+		tflog.Info(ctx, fmt.Sprintf("importing metrics drop filter %s for %s", matchingIds[0], d.Id()))
+		d.SetId(matchingIds[0])
+		return []*schema.ResourceData{d}, nil
+	default:
+		return nil, fmt.Errorf(errorMultipleMatchingDropMetrics, len(matchingIds), matchingIds)
+	}
+}
+
 // createCreateUpdateDropMetricsFromSchema creates a CreateUpdateDropMetric object from the schema
 func createCreateUpdateDropMetricsFromSchema(d *schema.ResourceData) drop_metrics.CreateUpdateDropMetric {
 	activeVal := d.Get(dropMetricsActive).(bool)
@@ -0,0 +1,515 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logzio/logzio_terraform_client/drop_metrics"
+)
+
+const (
+	dropMetricsSetAccountId        = "account_id"
+	dropMetricsSetNamePrefix       = "name_prefix"
+	dropMetricsSetDeleteUndeclared = "delete_undeclared"
+	dropMetricsSetRules            = "rules"
+	dropMetricsSetIdSeparator      = ":"
+)
+
+// resourceDropMetricsSet manages all the metrics drop filters of an account (optionally only those with a name prefix) as one resource.
+// Rules are matched to the metrics drop filters in Logz.io by name, so names must be unique within the set.
+func resourceDropMetricsSet() *schema.Resource {
+	var filterExprSchema = map[string]*schema.Schema{
+		dropMetricsExpressionLabelName: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		dropMetricsExpressionLabelValue: {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		dropMetricsExpressionCondition: {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateFunc: validation.StringInSlice(
+				[]string{
+					drop_metrics.ComparisonEq,
+					drop_metrics.ComparisonNotEq,
+					drop_metrics.ComparisonRegexMatch,
+					drop_metrics.ComparisonRegexNoMatch,
+				}, false),
+		},
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceDropMetricsSetCreate,
+		ReadContext:   resourceDropMetricsSetRead,
+		UpdateContext: resourceDropMetricsSetUpdate,
+		DeleteContext: resourceDropMetricsSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDropMetricsSetImport,
+		},
+		CustomizeDiff: validateDropMetricsSetRules,
+		Schema: map[string]*schema.Schema{
+			dropMetricsSetAccountId: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			dropMetricsSetNamePrefix: {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			dropMetricsSetDeleteUndeclared: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			dropMetricsSetRules: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						dropMetricsIdField: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						dropMetricsName: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						dropMetricsActive: {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						dropMetricsDropPolicy: {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  drop_metrics.DropPolicyBeforeProcessing,
+							ValidateFunc: validation.StringInSlice(
+								[]string{drop_metrics.DropPolicyBeforeProcessing, drop_metrics.DropPolicyBeforeStoring},
+								false),
+						},
+						dropMetricsFilters: {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Resource{
+								Schema: filterExprSchema,
+							},
+							Set: schema.HashResource(&schema.Resource{
+								Schema: filterExprSchema,
+							}),
+						},
+						dropMetricsFilterOperator: {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  drop_metrics.OperatorAnd,
+							ValidateFunc: validation.StringInSlice(
+								[]string{drop_metrics.OperatorAnd},
+								false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceDropMetricsSetCreate applies the declared rules of a new set
+func resourceDropMetricsSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := applyDropMetricsSet(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(dropMetricsSetId(int64Attr(d, dropMetricsSetAccountId), d.Get(dropMetricsSetNamePrefix).(string)))
+	return nil
+}
+
+// resourceDropMetricsSetRead gets all the metrics drop filters in the scope of the set with a single paged search
+func resourceDropMetricsSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	existing, err := searchDropMetricsSetScope(dropMetricsClient(m), int64Attr(d, dropMetricsSetAccountId), d.Get(dropMetricsSetNamePrefix).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	byName := dropMetricsByName(existing)
+
+	// Keep the order of the rules in the state, so reordering in the API doesn't show as a diff
+	rules := make([]drop_metrics.DropMetric, 0, len(existing))
+	for _, rule := range getDropMetricsSetRulesFromSchema(d) {
+		if dropMetric, ok := byName[rule.Name]; ok {
+			rules = append(rules, dropMetric)
+			delete(byName, rule.Name)
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("could not find metrics drop filter %s in account %d", rule.Name, int64Attr(d, dropMetricsSetAccountId)))
+		}
+	}
+
+	// Undeclared rules show on read only when they should be deleted, so they appear in the plan
+	if d.Get(dropMetricsSetDeleteUndeclared).(bool) {
+		rules = append(rules, sortedDropMetricsByName(byName)...)
+	}
+
+	setDropMetricsSetRules(d, rules)
+	return nil
+}
+
+// resourceDropMetricsSetUpdate applies only the differences between the declared rules and Logz.io
+func resourceDropMetricsSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := applyDropMetricsSet(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceDropMetricsSetDelete deletes all the metrics drop filters managed by the set
+func resourceDropMetricsSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var ids []int64
+	for _, rule := range getDropMetricsSetRulesFromSchema(d) {
+		if rule.Id != 0 {
+			ids = append(ids, rule.Id)
+		}
+	}
+
+	if len(ids) > 0 {
+		if err := dropMetricsClient(m).BulkDeleteDropMetrics(ids); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceDropMetricsSetImport imports all the metrics drop filters of an account, by "account_id" or "account_id:name_prefix"
+func resourceDropMetricsSetImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), dropMetricsSetIdSeparator, 2)
+	accountId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import id %s, expected account_id or account_id:name_prefix", d.Id())
+	}
+
+	namePrefix := ""
+	if len(parts) == 2 {
+		namePrefix = parts[1]
+	}
+
+	existing, err := searchDropMetricsSetScope(dropMetricsClient(m), accountId, namePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := dropMetricsByName(existing)
+
+	d.Set(dropMetricsSetAccountId, int(accountId))
+	d.Set(dropMetricsSetNamePrefix, namePrefix)
+	d.Set(dropMetricsSetDeleteUndeclared, false)
+	setDropMetricsSetRules(d, sortedDropMetricsByName(byName))
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateDropMetricsSetRules checks that rule names are unique and in the scope of the set
+func validateDropMetricsSetRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	namePrefix := d.Get(dropMetricsSetNamePrefix).(string)
+	names := make(map[string]bool)
+	for i, raw := range d.Get(dropMetricsSetRules).([]interface{}) {
+		if raw == nil {
+			continue
+		}
+
+		name := raw.(map[string]interface{})[dropMetricsName].(string)
+		if !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", dropMetricsSetRules, i, dropMetricsName)) {
+			continue
+		}
+
+		if names[name] {
+			return fmt.Errorf("%s.%d.%s: found more than one rule with name %s, rule names must be unique", dropMetricsSetRules, i, dropMetricsName, name)
+		}
+		names[name] = true
+
+		if !strings.HasPrefix(name, namePrefix) {
+			return fmt.Errorf("%s.%d.%s: rule name %s must start with %s %s", dropMetricsSetRules, i, dropMetricsName, name, dropMetricsSetNamePrefix, namePrefix)
+		}
+	}
+
+	return nil
+}
+
+// applyDropMetricsSet diffs the declared rules against the search results, and creates, updates and deletes only what changed.
+// Undeclared rules are deleted if they were previously managed by the set, or if delete_undeclared is set.
+// Metrics drop filters with the same name as another are deleted only if their name is declared, they were previously managed
+// or delete_undeclared is set, otherwise they're left as they are.
+func applyDropMetricsSet(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := dropMetricsClient(m)
+	accountId := int64Attr(d, dropMetricsSetAccountId)
+	existing, err := searchDropMetricsSetScope(client, accountId, d.Get(dropMetricsSetNamePrefix).(string))
+	if err != nil {
+		return err
+	}
+
+	previouslyManaged := make(map[int64]bool)
+	if !d.IsNewResource() {
+		oldRules, _ := d.GetChange(dropMetricsSetRules)
+		for _, raw := range oldRules.([]interface{}) {
+			previouslyManaged[int64(raw.(map[string]interface{})[dropMetricsIdField].(int))] = true
+		}
+	}
+
+	// Only one metrics drop filter per name can be matched to a rule, preferably the one the set already manages
+	byName := make(map[string]drop_metrics.DropMetric, len(existing))
+	var duplicates []drop_metrics.DropMetric
+	for _, dropMetric := range existing {
+		matched, ok := byName[dropMetric.Name]
+		if !ok {
+			byName[dropMetric.Name] = dropMetric
+			continue
+		}
+
+		if previouslyManaged[dropMetric.Id] && !previouslyManaged[matched.Id] {
+			byName[dropMetric.Name] = dropMetric
+			dropMetric = matched
+		}
+		duplicates = append(duplicates, dropMetric)
+	}
+
+	desired := getDropMetricsSetRulesFromSchema(d)
+	results := make(map[string]drop_metrics.DropMetric, len(desired))
+	var toCreate []drop_metrics.CreateUpdateDropMetric
+	for _, rule := range desired {
+		req := createUpdateDropMetricFromRule(accountId, rule)
+		current, ok := byName[rule.Name]
+		if !ok {
+			toCreate = append(toCreate, req)
+			continue
+		}
+
+		delete(byName, rule.Name)
+		if isSameDropMetricsRule(current, req) {
+			results[rule.Name] = current
+			continue
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("updating metrics drop filter %d (%s)", current.Id, rule.Name))
+		updated, err := client.UpdateDropMetric(current.Id, req)
+		if err != nil {
+			return fmt.Errorf("could not update metrics drop filter %d (%s): %v", current.Id, rule.Name, err)
+		}
+		results[rule.Name] = *updated
+	}
+
+	if len(toCreate) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("creating %d metrics drop filters in account %d", len(toCreate), accountId))
+		created, err := client.BulkCreateDropMetrics(toCreate)
+		if err != nil {
+			return fmt.Errorf("could not create metrics drop filters: %v", err)
+		}
+
+		for _, dropMetric := range created {
+			results[dropMetric.Name] = dropMetric
+		}
+	}
+
+	deleteUndeclared := d.Get(dropMetricsSetDeleteUndeclared).(bool)
+	var toDelete []int64
+	for _, dropMetric := range duplicates {
+		// A duplicate of a declared rule would keep dropping metrics after the rule changes
+		if deleteUndeclared || previouslyManaged[dropMetric.Id] || isDeclaredDropMetricsRule(desired, dropMetric.Name) {
+			toDelete = append(toDelete, dropMetric.Id)
+		} else {
+			tflog.Warn(ctx, fmt.Sprintf("found more than one metrics drop filter with name %s (ignoring id %d), it's not managed by the set", dropMetric.Name, dropMetric.Id))
+		}
+	}
+
+	for _, dropMetric := range byName {
+		if deleteUndeclared || previouslyManaged[dropMetric.Id] {
+			toDelete = append(toDelete, dropMetric.Id)
+		}
+	}
+
+	if len(toDelete) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("deleting %d undeclared metrics drop filters in account %d", len(toDelete), accountId))
+		if err = client.BulkDeleteDropMetrics(toDelete); err != nil {
+			return fmt.Errorf("could not delete undeclared metrics drop filters %v: %v", toDelete, err)
+		}
+	}
+
+	// The state is set from the API responses, since search results may not reflect the changes yet
+	rules := make([]drop_metrics.DropMetric, 0, len(desired))
+	for _, rule := range desired {
+		if dropMetric, ok := results[rule.Name]; ok {
+			rules = append(rules, dropMetric)
+		}
+	}
+
+	setDropMetricsSetRules(d, rules)
+	return nil
+}
+
+func isDeclaredDropMetricsRule(rules []drop_metrics.DropMetric, name string) bool {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchDropMetricsSetScope gets all the metrics drop filters of an account that start with the name prefix
+func searchDropMetricsSetScope(client *drop_metrics.DropMetricsClient, accountId int64, namePrefix string) ([]drop_metrics.DropMetric, error) {
+	req := drop_metrics.SearchDropMetricsRequest{
+		Filter:     &drop_metrics.SearchFilter{AccountIds: []int64{accountId}},
+		Pagination: &drop_metrics.Pagination{PageNumber: 1, PageSize: dropMetricsSearchPageSize},
+	}
+
+	var result []drop_metrics.DropMetric
+	for {
+		page, err := client.SearchDropMetrics(req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dropMetric := range page {
+			if dropMetric.AccountId == accountId && strings.HasPrefix(dropMetric.Name, namePrefix) {
+				result = append(result, dropMetric)
+			}
+		}
+
+		if len(page) < dropMetricsSearchPageSize {
+			break
+		}
+		req.Pagination.PageNumber++
+	}
+
+	return result, nil
+}
+
+// isSameDropMetricsRule checks whether a metrics drop filter already matches the declared rule
+func isSameDropMetricsRule(dropMetric drop_metrics.DropMetric, req drop_metrics.CreateUpdateDropMetric) bool {
+	if dropMetric.Active != *req.Active ||
+		dropMetric.DropPolicy != req.DropPolicy ||
+		dropMetric.Filter.Operator != req.Filter.Operator ||
+		len(dropMetric.Filter.Expression) != len(req.Filter.Expression) {
+		return false
+	}
+
+	keys := make(map[string]struct{}, len(dropMetric.Filter.Expression))
+	for _, e := range dropMetric.Filter.Expression {
+		keys[exprKey(e)] = struct{}{}
+	}
+
+	for _, e := range req.Filter.Expression {
+		if _, ok := keys[exprKey(e)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// createUpdateDropMetricFromRule creates a CreateUpdateDropMetric object from a rule of the set
+func createUpdateDropMetricFromRule(accountId int64, rule drop_metrics.DropMetric) drop_metrics.CreateUpdateDropMetric {
+	active := rule.Active
+	return drop_metrics.CreateUpdateDropMetric{
+		AccountId:  accountId,
+		Name:       rule.Name,
+		Active:     &active,
+		DropPolicy: rule.DropPolicy,
+		Filter:     rule.Filter,
+	}
+}
+
+// getDropMetricsSetRulesFromSchema gets the rules of the set from the schema
+func getDropMetricsSetRulesFromSchema(d *schema.ResourceData) []drop_metrics.DropMetric {
+	rawRules := d.Get(dropMetricsSetRules).([]interface{})
+	rules := make([]drop_metrics.DropMetric, 0, len(rawRules))
+	for _, raw := range rawRules {
+		rule := raw.(map[string]interface{})
+		var expressions []drop_metrics.FilterExpression
+		for _, rawExpression := range rule[dropMetricsFilters].(*schema.Set).List() {
+			expression := rawExpression.(map[string]interface{})
+			expressions = append(expressions, drop_metrics.FilterExpression{
+				Name:             expression[dropMetricsExpressionLabelName].(string),
+				Value:            expression[dropMetricsExpressionLabelValue].(string),
+				ComparisonFilter: expression[dropMetricsExpressionCondition].(string),
+			})
+		}
+
+		rules = append(rules, drop_metrics.DropMetric{
+			Id:         int64(rule[dropMetricsIdField].(int)),
+			Name:       rule[dropMetricsName].(string),
+			Active:     rule[dropMetricsActive].(bool),
+			DropPolicy: rule[dropMetricsDropPolicy].(string),
+			Filter: drop_metrics.FilterObject{
+				Operator:   rule[dropMetricsFilterOperator].(string),
+				Expression: expressions,
+			},
+		})
+	}
+
+	return rules
+}
+
+// setDropMetricsSetRules sets the rules of the set from DropMetric objects
+func setDropMetricsSetRules(d *schema.ResourceData, dropMetrics []drop_metrics.DropMetric) {
+	rules := make([]map[string]interface{}, 0, len(dropMetrics))
+	for _, dropMetric := range dropMetrics {
+		rules = append(rules, map[string]interface{}{
+			dropMetricsIdField:        int(dropMetric.Id),
+			dropMetricsName:           dropMetric.Name,
+			dropMetricsActive:         dropMetric.Active,
+			dropMetricsDropPolicy:     dropMetric.DropPolicy,
+			dropMetricsFilters:        dropMetricsFilterExpressionToInterface(dropMetric.Filter.Expression),
+			dropMetricsFilterOperator: dropMetric.Filter.Operator,
+		})
+	}
+
+	d.Set(dropMetricsSetRules, rules)
+}
+
+// dropMetricsByName maps metrics drop filters by name. If a name is used more than once, the first one is kept.
+func dropMetricsByName(dropMetrics []drop_metrics.DropMetric) map[string]drop_metrics.DropMetric {
+	byName := make(map[string]drop_metrics.DropMetric, len(dropMetrics))
+	for _, dropMetric := range dropMetrics {
+		if _, ok := byName[dropMetric.Name]; !ok {
+			byName[dropMetric.Name] = dropMetric
+		}
+	}
+
+	return byName
+}
+
+// sortedDropMetricsByName returns the metrics drop filters sorted by name, so reads are deterministic
+func sortedDropMetricsByName(byName map[string]drop_metrics.DropMetric) []drop_metrics.DropMetric {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]drop_metrics.DropMetric, 0, len(names))
+	for _, name := range names {
+		result = append(result, byName[name])
+	}
+
+	return result
+}
+
+// dropMetricsSetId returns the id of the set, "account_id" or "account_id:name_prefix"
+func dropMetricsSetId(accountId int64, namePrefix string) string {
+	if namePrefix == "" {
+		return int64ToStr(accountId)
+	}
+
+	return int64ToStr(accountId) + dropMetricsSetIdSeparator + namePrefix
+}
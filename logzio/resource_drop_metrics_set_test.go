@@ -0,0 +1,161 @@
+package logzio
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/logzio/logzio_terraform_client/drop_metrics"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+const (
+	dropMetricsSetResourceCreate               = "create_drop_metrics_set"
+	dropMetricsSetResourceUpdate               = "update_drop_metrics_set"
+	dropMetricsSetResourceCreateDuplicateNames = "create_drop_metrics_set_duplicate_names"
+	dropMetricsSetResourceUnmanagedDuplicates  = "create_drop_metrics_set_unmanaged_duplicates"
+)
+
+func TestAccLogzioDropMetricsSet_CreateUpdateDropMetricsSet(t *testing.T) {
+	setName := "test_create_update_drop_metrics_set"
+	resourceName := "logzio_drop_metrics_set." + setName
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: resourceTestDropMetrics(setName, dropMetricsSetResourceCreate, accountId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetAccountId, accountId),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, dropMetricsSetRules+".0."+dropMetricsIdField),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".1."+dropMetricsFilters+".#", "2"),
+				),
+			},
+			{
+				Config: resourceTestDropMetrics(setName, dropMetricsSetResourceUpdate, accountId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".#", "2"),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".0."+dropMetricsName, "tf-test-set-second"),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".0."+dropMetricsActive, "false"),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".1."+dropMetricsName, "tf-test-set-third"),
+					resource.TestCheckResourceAttr(resourceName, dropMetricsSetRules+".1."+dropMetricsDropPolicy, drop_metrics.DropPolicyBeforeStoring),
+				),
+			},
+			{
+				Config:            resourceTestDropMetrics(setName, dropMetricsSetResourceUpdate, accountId),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLogzioDropMetricsSet_CreateDropMetricsSetDuplicateNames(t *testing.T) {
+	setName := "test_create_drop_metrics_set_duplicate_names"
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      resourceTestDropMetrics(setName, dropMetricsSetResourceCreateDuplicateNames, accountId),
+				ExpectError: regexp.MustCompile("rule names must be unique"),
+			},
+		},
+	})
+}
+
+func TestAccLogzioDropMetricsSet_KeepUnmanagedDuplicates(t *testing.T) {
+	setName := "test_drop_metrics_set_unmanaged_duplicates"
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The duplicates are created before the set, and would show as a diff if the set deleted them
+				Config: resourceTestDropMetrics(setName, dropMetricsSetResourceUnmanagedDuplicates, accountId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logzio_drop_metrics_set."+setName, dropMetricsSetRules+".#", "1"),
+					resource.TestCheckResourceAttrSet("logzio_drop_metrics."+setName+"_unmanaged_first", dropMetricsIdField),
+					resource.TestCheckResourceAttrSet("logzio_drop_metrics."+setName+"_unmanaged_second", dropMetricsIdField),
+				),
+			},
+			{
+				// Refreshing the duplicates checks they still exist after the set was applied
+				Config:   resourceTestDropMetrics(setName, dropMetricsSetResourceUnmanagedDuplicates, accountId),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestDropMetricsSet_IsSameDropMetricsRule(t *testing.T) {
+	active := true
+	req := drop_metrics.CreateUpdateDropMetric{
+		AccountId:  1234,
+		Name:       "tf-test-set-first",
+		Active:     &active,
+		DropPolicy: drop_metrics.DropPolicyBeforeProcessing,
+		Filter: drop_metrics.FilterObject{
+			Operator: drop_metrics.OperatorAnd,
+			Expression: []drop_metrics.FilterExpression{
+				{Name: "__name__", Value: "my_metric", ComparisonFilter: drop_metrics.ComparisonEq},
+				{Name: "env", Value: "dev", ComparisonFilter: drop_metrics.ComparisonNotEq},
+			},
+		},
+	}
+
+	// Same expressions in a different order
+	dropMetric := drop_metrics.DropMetric{
+		Id:         1,
+		AccountId:  1234,
+		Name:       "tf-test-set-first",
+		Active:     true,
+		DropPolicy: drop_metrics.DropPolicyBeforeProcessing,
+		Filter: drop_metrics.FilterObject{
+			Operator: drop_metrics.OperatorAnd,
+			Expression: []drop_metrics.FilterExpression{
+				{Name: "env", Value: "dev", ComparisonFilter: drop_metrics.ComparisonNotEq},
+				{Name: "__name__", Value: "my_metric", ComparisonFilter: drop_metrics.ComparisonEq},
+			},
+		},
+	}
+
+	if !isSameDropMetricsRule(dropMetric, req) {
+		t.Error("expected rules with the same expressions in a different order to be the same")
+	}
+
+	dropMetric.Filter.Expression[0].ComparisonFilter = drop_metrics.ComparisonEq
+	if isSameDropMetricsRule(dropMetric, req) {
+		t.Error("expected rules with different conditions not to be the same")
+	}
+
+	dropMetric.Filter.Expression[0].ComparisonFilter = drop_metrics.ComparisonNotEq
+	dropMetric.Active = false
+	if isSameDropMetricsRule(dropMetric, req) {
+		t.Error("expected rules with different active status not to be the same")
+	}
+}
@@ -60,6 +60,41 @@ func TestAccLogzioDropMetric_CreateDropMetricSimple(t *testing.T) {
 	})
 }
 
+func TestAccLogzioDropMetric_ImportDropMetricByName(t *testing.T) {
+	filterName := "test_import_drop_metrics_by_name"
+	resourceName := "logzio_drop_metrics." + filterName
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: resourceTestDropMetrics(filterName, dropMetricResourceCreateWithName, accountId),
+			},
+			{
+				Config:            resourceTestDropMetrics(filterName, dropMetricResourceCreateWithName, accountId),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     accountId + ":test-drop-metrics-filter",
+				ImportStateVerify: true,
+			},
+			{
+				Config:        resourceTestDropMetrics(filterName, dropMetricResourceCreateWithName, accountId),
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: accountId + ":test-drop-metrics-filter-missing",
+				ExpectError:   regexp.MustCompile("couldn't find metrics drop filter with name test-drop-metrics-filter-missing"),
+			},
+		},
+	})
+}
+
 func TestAccLogzioDropMetric_CreateDropMetricComplex(t *testing.T) {
 	filterName := "test_create_drop_metrics_complex"
 	resourceName := "logzio_drop_metrics." + filterName
@@ -243,6 +243,7 @@ func resourceEndpoint() *schema.Resource {
 					},
 				},
 			},
+			adoptExisting: adoptExistingSchema(),
 		},
 	}
 }
@@ -255,6 +256,18 @@ func endpointClient(m interface{}) *endpoints.EndpointsClient {
 }
 
 func resourceEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if shouldAdoptExisting(d, m) {
+		id, err := findEndpointIdToAdopt(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if id != "" {
+			d.SetId(id)
+			return resourceEndpointUpdate(ctx, d, m)
+		}
+	}
+
 	createEndpoint := getCreateOrUpdateEndpointFromSchema(d)
 	endpoint, err := endpointClient(m).CreateEndpoint(createEndpoint)
 	if err != nil {
@@ -449,3 +462,63 @@ func setEndpoint(d *schema.ResourceData, endpoint *endpoints.Endpoint) {
 
 	d.Set(typeLowerCase, set)
 }
+
+// findEndpointIdToAdopt searches for a notification endpoint with the same title and type.
+// Endpoints of another type are never adopted, since they can't be updated to the configured type.
+func findEndpointIdToAdopt(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
+	title := d.Get(endpointTitle).(string)
+	typeLowerCase := strings.ToLower(d.Get(endpointType).(string))
+	list, err := endpointClient(m).ListEndpoints()
+	if err != nil {
+		return "", err
+	}
+
+	var matchingIds []string
+	for _, endpoint := range list {
+		if endpoint.Title != title {
+			continue
+		}
+
+		if strings.ToLower(endpoint.Type) != typeLowerCase {
+			tflog.Info(ctx, fmt.Sprintf("not adopting notification endpoint %d with title %q, its type %s is not %s", endpoint.Id, title, endpoint.Type, typeLowerCase))
+			continue
+		}
+
+		matchingIds = append(matchingIds, strconv.FormatInt(int64(endpoint.Id), 10))
+	}
+
+	return findIdToAdopt(ctx, typeLowerCase+" notification endpoint", endpointTitle, title, matchingIds)
+}
+
+// endpointIdsByTitles resolves notification endpoint titles to their ids.
+// Returns an error if a title doesn't match any endpoint, or matches more than one.
+func endpointIdsByTitles(m interface{}, titles []string) ([]int, error) {
+	if len(titles) == 0 {
+		return nil, nil
+	}
+
+	list, err := endpointClient(m).ListEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("could not list notification endpoints: %v", err)
+	}
+
+	idsByTitle := make(map[string][]int)
+	for _, endpoint := range list {
+		idsByTitle[endpoint.Title] = append(idsByTitle[endpoint.Title], int(endpoint.Id))
+	}
+
+	var ids []int
+	for _, title := range titles {
+		matching := idsByTitle[title]
+		switch len(matching) {
+		case 0:
+			return nil, fmt.Errorf("couldn't find notification endpoint with title %q", title)
+		case 1:
+			ids = append(ids, matching[0])
+		default:
+			return nil, fmt.Errorf("found %d notification endpoints with title %q (ids: %v), use the endpoint id instead", len(matching), title, matching)
+		}
+	}
+
+	return ids, nil
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/logzio/logzio_terraform_client/endpoints"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"log"
 	"net/http"
 	"os"
 	"regexp"
+	"strconv"
 	"testing"
 )
 
@@ -820,3 +822,68 @@ func createCustomEndpoint(name string) string {
 	}
 	return fmt.Sprintf(fmt.Sprintf("%s", content), name)
 }
+
+func TestAccLogzioEndpoint_AdoptExisting(t *testing.T) {
+	resourceName := "logzio_endpoint.adopt_existing"
+	title := "tf_test_adopt_" + getRandomId()
+	var existingId string
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The endpoint is created outside of terraform, so the resource can only get its id by adopting it
+				PreConfig: func() {
+					endpoint, err := endpointClient(testAccConfig()).CreateEndpoint(endpoints.CreateOrUpdateEndpoint{
+						Title:       title,
+						Description: "created outside of terraform",
+						Type:        endpoints.EndpointTypeSlack,
+						Url:         testsUrl,
+					})
+					if err != nil {
+						t.Fatalf("could not create the endpoint to adopt: %v", err)
+					}
+
+					existingId = strconv.FormatInt(int64(endpoint.Id), 10)
+				},
+				Config: fmt.Sprintf(utils.ReadFixtureFromFile("adopt_slack_endpoint.tf"), title),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.ID; id != existingId {
+							return fmt.Errorf("expected endpoint %s to be adopted, got endpoint %s", existingId, id)
+						}
+
+						return nil
+					},
+					resource.TestCheckResourceAttr(resourceName, "description", "adopted by terraform"),
+					resource.TestCheckResourceAttr(resourceName, "slack.0.url", testsUrlUpdate),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogzioEndpoint_AdoptExistingAmbiguousTitle(t *testing.T) {
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: utils.ReadFixtureFromFile("create_slack_endpoints_same_title.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("logzio_endpoint.same_title_first", "title", "tf_test_adopt_same_title"),
+					resource.TestCheckResourceAttr("logzio_endpoint.same_title_second", "title", "tf_test_adopt_same_title"),
+				),
+			},
+			{
+				Config: utils.ReadFixtureFromFile("create_slack_endpoints_same_title.tf") +
+					utils.ReadFixtureFromFile("adopt_slack_endpoint_same_title.tf"),
+				ExpectError: regexp.MustCompile("refusing to adopt"),
+			},
+		},
+	})
+}
@@ -52,6 +52,7 @@ func resourceGrafanaFolder() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			adoptExisting: adoptExistingSchema(),
 		},
 	}
 }
@@ -70,6 +71,18 @@ func resourceGrafanaFolderCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if shouldAdoptExisting(d, m) {
+		uid, err := findGrafanaFolderUidToAdopt(ctx, d, client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if uid != "" {
+			d.SetId(uid)
+			return resourceGrafanaFolderRead(ctx, d, m)
+		}
+	}
+
 	req := getCreateGrafanaFolderFromSchema(d)
 	result, err := client.CreateGrafanaFolder(req)
 	if err != nil {
@@ -178,6 +191,24 @@ func getCreateGrafanaFolderFromSchema(d *schema.ResourceData) grafana_folders.Cr
 	}
 }
 
+// findGrafanaFolderUidToAdopt searches for a grafana folder with the same title
+func findGrafanaFolderUidToAdopt(ctx context.Context, d *schema.ResourceData, client *grafana_folders.GrafanaFolderClient) (string, error) {
+	title := d.Get(grafanaFolderTitle).(string)
+	folders, err := client.ListGrafanaFolders()
+	if err != nil {
+		return "", err
+	}
+
+	var matchingUids []string
+	for _, folder := range folders {
+		if folder.Title == title {
+			matchingUids = append(matchingUids, folder.Uid)
+		}
+	}
+
+	return findIdToAdopt(ctx, "grafana folder", grafanaFolderTitle, title, matchingUids)
+}
+
 func getUpdateGrafanaFolderFromSchema(d *schema.ResourceData) grafana_folders.CreateUpdateFolder {
 	folder := getCreateGrafanaFolderFromSchema(d)
 	folder.Overwrite = true
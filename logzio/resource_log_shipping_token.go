@@ -7,11 +7,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/logzio/logzio_terraform_client/log_shipping_tokens"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -25,7 +27,15 @@ const (
 	logShippingTokenCreatedBy = "created_by"
 	logShippingTokenTokenId   = "token_id"
 
-	logShippingTokenRetryAttempts = 8
+	logShippingTokenRotationTrigger        = "rotation_trigger"
+	logShippingTokenRotateAfterDays        = "rotate_after_days"
+	logShippingTokenOverlapHours           = "overlap_hours"
+	logShippingTokenPreviousToken          = "previous_token"
+	logShippingTokenPreviousTokenId        = "previous_token_id"
+	logShippingTokenPreviousTokenExpiresAt = "previous_token_expires_at"
+
+	logShippingTokenRetryAttempts       = 8
+	logShippingTokenDefaultOverlapHours = 24
 )
 
 func resourceLogShippingToken() *schema.Resource {
@@ -37,6 +47,7 @@ func resourceLogShippingToken() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeLogShippingTokenRotation,
 
 		Schema: map[string]*schema.Schema{
 			logShippingTokenTokenId: {
@@ -72,12 +83,56 @@ func resourceLogShippingToken() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			logShippingTokenRotationTrigger: {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			logShippingTokenRotateAfterDays: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			logShippingTokenOverlapHours: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			logShippingTokenPreviousToken: {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			logShippingTokenPreviousTokenId: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			logShippingTokenPreviousTokenExpiresAt: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			adoptExisting: adoptExistingSchema(),
 		},
 	}
 }
 
 // resourceLogShippingTokenCreate creates a new log shipping token in logz.io
 func resourceLogShippingTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if shouldAdoptExisting(d, m) {
+		id, err := findLogShippingTokenIdToAdopt(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if id != "" {
+			d.SetId(id)
+			// enabled is optional, an adopted token keeps its status unless it's set in the config
+			if enabled, _ := optionalBoolPtr(d, logShippingTokenEnabled); enabled == nil {
+				return resourceLogShippingTokenRead(ctx, d, m)
+			}
+			return resourceLogShippingTokenUpdate(ctx, d, m)
+		}
+	}
+
 	createToken := log_shipping_tokens.CreateLogShippingToken{Name: d.Get(logShippingTokenName).(string)}
 	tokenLimits, err := logShippingTokenClient(m).GetLogShippingLimitsToken()
 	if err != nil {
@@ -99,6 +154,50 @@ func resourceLogShippingTokenCreate(ctx context.Context, d *schema.ResourceData,
 		tokenLimits.MaxAllowedTokens, tokenLimits.NumOfEnabledTokens)
 }
 
+// customizeLogShippingTokenRotation plans a rotation when rotation_trigger changes or the token is older than rotate_after_days,
+// and plans retiring the previous token once its overlap is over
+func customizeLogShippingTokenRotation(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	if isLogShippingTokenRotationDue(d, now) {
+		for _, key := range []string{
+			logShippingTokenTokenId, logShippingTokenToken,
+			logShippingTokenCreatedAt, logShippingTokenCreatedBy, logShippingTokenUpdatedAt, logShippingTokenUpdatedBy,
+			logShippingTokenPreviousToken, logShippingTokenPreviousTokenId, logShippingTokenPreviousTokenExpiresAt,
+		} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	expiresAt := d.Get(logShippingTokenPreviousTokenExpiresAt).(int)
+	if d.Get(logShippingTokenPreviousTokenId).(int) != 0 && int64(expiresAt) <= now {
+		for _, key := range []string{logShippingTokenPreviousToken, logShippingTokenPreviousTokenId, logShippingTokenPreviousTokenExpiresAt} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isLogShippingTokenRotationDue(d *schema.ResourceDiff, now int64) bool {
+	if d.HasChange(logShippingTokenRotationTrigger) {
+		return true
+	}
+
+	rotateAfterDays := d.Get(logShippingTokenRotateAfterDays).(int)
+	createdAt := d.Get(logShippingTokenCreatedAt).(int)
+	return rotateAfterDays > 0 && createdAt > 0 && now >= int64(createdAt)+int64(rotateAfterDays)*int64((24*time.Hour).Seconds())
+}
+
 // resourceLogShippingTokenRead gets log shipping token by id
 func resourceLogShippingTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id, err := utils.IdFromResourceData(d)
@@ -119,11 +218,160 @@ func resourceLogShippingTokenRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	setLogShippingToken(d, token)
+
+	if previousId := d.Get(logShippingTokenPreviousTokenId).(int); previousId != 0 {
+		_, err = logShippingTokenClient(m).GetLogShippingToken(int32(previousId))
+		if err != nil {
+			if !strings.Contains(err.Error(), "missing log shipping") {
+				return diag.FromErr(err)
+			}
+
+			tflog.Info(ctx, fmt.Sprintf("previous log shipping token %d was deleted outside of terraform", previousId))
+			setPreviousLogShippingToken(d, "", 0, 0)
+		}
+	}
+
 	return nil
 }
 
-// resourceLogShippingTokenUpdate updates log shipping token by id
+// resourceLogShippingTokenUpdate rotates the token or retires the previous token if it was planned, and updates log shipping token by id
 func resourceLogShippingTokenUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// The token and the previous token are only planned to change by customizeLogShippingTokenRotation
+	if d.HasChange(logShippingTokenToken) {
+		diags = rotateLogShippingToken(ctx, d, m)
+	} else if d.HasChange(logShippingTokenPreviousTokenId) {
+		diags = retirePreviousLogShippingToken(ctx, d, m)
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, updateLogShippingToken(ctx, d, m)...)
+}
+
+// rotateLogShippingToken creates a new token with the same name, and keeps the current token as the previous token for the overlap
+func rotateLogShippingToken(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := logShippingTokenClient(m)
+
+	// The new token is enabled before the current one is disabled, so the rotation needs a free token
+	tokenLimits, err := client.GetLogShippingLimitsToken()
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if tokenLimits.NumOfEnabledTokens >= tokenLimits.MaxAllowedTokens {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "cannot rotate log shipping token, the account reached its max allowed tokens",
+			Detail: fmt.Sprintf("The new token is created while the current token is still enabled. max allowed tokens for account: %d. number of enabled tokens: %d",
+				tokenLimits.MaxAllowedTokens, tokenLimits.NumOfEnabledTokens),
+		})
+	}
+
+	newToken, err := client.CreateLogShippingToken(log_shipping_tokens.CreateLogShippingToken{Name: d.Get(logShippingTokenName).(string)})
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	// A previous token that's still in its overlap is retired once the new token exists, so there's at most one previous token
+	oldPreviousToken, _ := d.GetChange(logShippingTokenPreviousToken)
+	oldPreviousId, _ := d.GetChange(logShippingTokenPreviousTokenId)
+	oldPreviousExpiresAt, _ := d.GetChange(logShippingTokenPreviousTokenExpiresAt)
+	if oldPreviousId.(int) != 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("retiring previous log shipping token %d before its overlap is over", oldPreviousId.(int)),
+			Detail:   "The token was rotated again while the previous token was still in its overlap.",
+		})
+		if err = retireLogShippingToken(client, int32(oldPreviousId.(int))); err != nil {
+			// The rotation is undone, so the previous token stays tracked until its overlap is over
+			if deleteErr := retireLogShippingToken(client, newToken.Id); deleteErr != nil {
+				tflog.Error(ctx, fmt.Sprintf("could not delete log shipping token %d created for the rotation: %v", newToken.Id, deleteErr))
+			}
+			setPreviousLogShippingToken(d, oldPreviousToken.(string), oldPreviousId.(int), oldPreviousExpiresAt.(int))
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	currentId, err := utils.IdFromResourceData(d)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	currentToken, _ := d.GetChange(logShippingTokenToken)
+
+	d.SetId(strconv.FormatInt(int64(newToken.Id), 10))
+	tflog.Info(ctx, fmt.Sprintf("rotated log shipping token %d to %d", currentId, newToken.Id))
+
+	overlap := getLogShippingTokenOverlap(d)
+	if overlap == 0 {
+		if err = retireLogShippingToken(client, int32(currentId)); err != nil {
+			// The replaced token is kept as an expired previous token, so the next apply retires it
+			setPreviousLogShippingToken(d, currentToken.(string), int(currentId), int(time.Now().Unix()))
+			return append(diags, diag.FromErr(err)...)
+		}
+
+		setPreviousLogShippingToken(d, "", 0, 0)
+		return diags
+	}
+
+	setPreviousLogShippingToken(d, currentToken.(string), int(currentId), int(time.Now().Add(overlap).Unix()))
+	return diags
+}
+
+// retirePreviousLogShippingToken disables and deletes the previous token once its overlap is over
+func retirePreviousLogShippingToken(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	previousId, _ := d.GetChange(logShippingTokenPreviousTokenId)
+	if previousId.(int) != 0 {
+		tflog.Info(ctx, fmt.Sprintf("overlap is over, retiring previous log shipping token %d", previousId.(int)))
+		if err := retireLogShippingToken(logShippingTokenClient(m), int32(previousId.(int))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	setPreviousLogShippingToken(d, "", 0, 0)
+	return nil
+}
+
+// retireLogShippingToken disables a token, so shippers that still use it stop being accepted, and then deletes it
+func retireLogShippingToken(client *log_shipping_tokens.LogShippingTokensClient, id int32) error {
+	token, err := client.GetLogShippingToken(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "missing log shipping") {
+			return nil
+		}
+
+		return err
+	}
+
+	if token.Enabled {
+		_, err = client.UpdateLogShippingToken(id, log_shipping_tokens.UpdateLogShippingToken{Name: token.Name, Enabled: strconv.FormatBool(false)})
+		if err != nil {
+			return fmt.Errorf("could not disable log shipping token %d: %w", id, err)
+		}
+	}
+
+	if err = client.DeleteLogShippingToken(id); err != nil {
+		return fmt.Errorf("could not delete log shipping token %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// getLogShippingTokenOverlap returns overlap_hours, which defaults to logShippingTokenDefaultOverlapHours when not set
+func getLogShippingTokenOverlap(d *schema.ResourceData) time.Duration {
+	overlapHours, ok := d.GetOkExists(logShippingTokenOverlapHours)
+	if !ok {
+		return logShippingTokenDefaultOverlapHours * time.Hour
+	}
+
+	return time.Duration(overlapHours.(int)) * time.Hour
+}
+
+// updateLogShippingToken updates log shipping token by id
+func updateLogShippingToken(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id, err := utils.IdFromResourceData(d)
 	if err != nil {
 		return diag.FromErr(err)
@@ -189,10 +437,64 @@ func resourceLogShippingTokenDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if previousId := d.Get(logShippingTokenPreviousTokenId).(int); previousId != 0 {
+		if err = retireLogShippingToken(logShippingTokenClient(m), int32(previousId)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	d.SetId("")
 	return nil
 }
 
+// findLogShippingTokenIdToAdopt searches the enabled and disabled log shipping tokens for a token with the same name
+func findLogShippingTokenIdToAdopt(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
+	name := d.Get(logShippingTokenName).(string)
+	client := logShippingTokenClient(m)
+	var matchingIds []string
+	for _, enabled := range []bool{true, false} {
+		tokens, err := retrieveAllLogShippingTokens(client, enabled)
+		if err != nil {
+			return "", err
+		}
+
+		for _, token := range tokens {
+			if token.Name == name {
+				matchingIds = append(matchingIds, strconv.FormatInt(int64(token.Id), 10))
+			}
+		}
+	}
+
+	return findIdToAdopt(ctx, "log shipping token", logShippingTokenName, name, matchingIds)
+}
+
+// retrieveAllLogShippingTokens retrieves all the enabled or disabled log shipping tokens, page by page
+func retrieveAllLogShippingTokens(client *log_shipping_tokens.LogShippingTokensClient, enabled bool) ([]log_shipping_tokens.LogShippingToken, error) {
+	retrieveRequest := log_shipping_tokens.RetrieveLogShippingTokensRequest{
+		Filter: log_shipping_tokens.ShippingTokensFilterRequest{Enabled: strconv.FormatBool(enabled)},
+		Pagination: log_shipping_tokens.ShippingTokensPaginationRequest{
+			PageNumber: 1,
+			PageSize:   25,
+		},
+	}
+
+	var tokens []log_shipping_tokens.LogShippingToken
+	for {
+		response, err := client.RetrieveLogShippingTokens(retrieveRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, response.Results...)
+		if len(response.Results) == 0 || len(tokens) >= int(response.Total) {
+			break
+		}
+		retrieveRequest.Pagination.PageNumber += 1
+	}
+
+	return tokens, nil
+}
+
 func logShippingTokenClient(m interface{}) *log_shipping_tokens.LogShippingTokensClient {
 	var client *log_shipping_tokens.LogShippingTokensClient
 	client, _ = log_shipping_tokens.New(m.(Config).apiToken, m.(Config).baseUrl)
@@ -209,3 +511,9 @@ func setLogShippingToken(d *schema.ResourceData, token *log_shipping_tokens.LogS
 	d.Set(logShippingTokenCreatedAt, token.CreatedAt)
 	d.Set(logShippingTokenCreatedBy, token.CreatedBy)
 }
+
+func setPreviousLogShippingToken(d *schema.ResourceData, token string, id int, expiresAt int) {
+	d.Set(logShippingTokenPreviousToken, token)
+	d.Set(logShippingTokenPreviousTokenId, id)
+	d.Set(logShippingTokenPreviousTokenExpiresAt, expiresAt)
+}
@@ -1,18 +1,25 @@
 package logzio
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"log"
 	"os"
 	"regexp"
+	"strconv"
 	"testing"
+	"time"
 )
 
 const (
 	logShippingTokenResourceCreateToken string = "create_log_shipping_token"
 	logShippingTokenResourceUpdateToken string = "update_log_shipping_token"
+	logShippingTokenResourceRotateV1    string = "rotate_log_shipping_token_v1"
+	logShippingTokenResourceRotateV2    string = "rotate_log_shipping_token_v2"
+	logShippingTokenResourceNoOverlap   string = "rotate_log_shipping_token_no_overlap"
 )
 
 func TestAccLogzioLogShippingToken_CreateLogShippingToken(t *testing.T) {
@@ -111,6 +118,138 @@ func TestAccLogzioLogShippingToken_UpdateLogShippingTokenEmptyName(t *testing.T)
 	})
 }
 
+func TestAccLogzioLogShippingToken_RotateLogShippingToken(t *testing.T) {
+	tokenName := "tf_test_rotate"
+	resourceName := "logzio_log_shipping_token." + tokenName
+	var firstTokenId string
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: resourceTestLogShippingToken(tokenName, logShippingTokenResourceRotateV1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, logShippingTokenPreviousTokenId, "0"),
+					func(s *terraform.State) error {
+						firstTokenId = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Rotate - the first token is kept as the previous token for the overlap
+				Config: resourceTestLogShippingToken(tokenName, logShippingTokenResourceRotateV2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, logShippingTokenPreviousToken),
+					resource.TestCheckResourceAttrSet(resourceName, logShippingTokenPreviousTokenExpiresAt),
+					func(s *terraform.State) error {
+						state := s.RootModule().Resources[resourceName].Primary
+						if state.ID == firstTokenId {
+							return fmt.Errorf("expected a new token after rotation, got the same token %s", state.ID)
+						}
+						if state.Attributes[logShippingTokenPreviousTokenId] != firstTokenId {
+							return fmt.Errorf("expected previous token id %s, got %s", firstTokenId, state.Attributes[logShippingTokenPreviousTokenId])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Rotate without overlap - both older tokens are retired
+				Config: resourceTestLogShippingToken(tokenName, logShippingTokenResourceNoOverlap),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, logShippingTokenPreviousTokenId, "0"),
+					resource.TestCheckResourceAttr(resourceName, logShippingTokenPreviousToken, ""),
+					resource.TestCheckResourceAttr(resourceName, logShippingTokenEnabled, "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestLogShippingToken_CustomizeLogShippingTokenRotation(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name             string
+		state            map[string]string
+		config           map[string]interface{}
+		expectRotate     bool
+		expectRetirement bool
+	}{
+		{
+			name:   "no rotation",
+			state:  map[string]string{logShippingTokenCreatedAt: strconv.FormatInt(now.Unix(), 10)},
+			config: map[string]interface{}{logShippingTokenRotateAfterDays: 30},
+		},
+		{
+			name:         "rotate after days",
+			state:        map[string]string{logShippingTokenCreatedAt: strconv.FormatInt(now.Add(-31*24*time.Hour).Unix(), 10)},
+			config:       map[string]interface{}{logShippingTokenRotateAfterDays: 30},
+			expectRotate: true,
+		},
+		{
+			name:         "rotation trigger changed",
+			state:        map[string]string{logShippingTokenRotationTrigger: "v1"},
+			config:       map[string]interface{}{logShippingTokenRotationTrigger: "v2"},
+			expectRotate: true,
+		},
+		{
+			name: "overlap not over",
+			state: map[string]string{
+				logShippingTokenPreviousTokenId:        "2",
+				logShippingTokenPreviousTokenExpiresAt: strconv.FormatInt(now.Add(time.Hour).Unix(), 10),
+			},
+			config: map[string]interface{}{},
+		},
+		{
+			name: "overlap over",
+			state: map[string]string{
+				logShippingTokenPreviousTokenId:        "2",
+				logShippingTokenPreviousTokenExpiresAt: strconv.FormatInt(now.Add(-time.Hour).Unix(), 10),
+			},
+			config:           map[string]interface{}{},
+			expectRetirement: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := map[string]string{
+				"id":                    "1",
+				logShippingTokenName:    "tf_test_rotate",
+				logShippingTokenEnabled: "true",
+				logShippingTokenToken:   "some_token",
+			}
+			for key, value := range tc.state {
+				attributes[key] = value
+			}
+			config := map[string]interface{}{logShippingTokenName: "tf_test_rotate"}
+			for key, value := range tc.config {
+				config[key] = value
+			}
+
+			diff, err := resourceLogShippingToken().SimpleDiff(context.Background(),
+				&terraform.InstanceState{ID: "1", Attributes: attributes},
+				terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			isComputed := func(key string) bool {
+				return diff != nil && diff.Attributes[key] != nil && diff.Attributes[key].NewComputed
+			}
+			if isComputed(logShippingTokenToken) != tc.expectRotate {
+				t.Errorf("expected rotation to be %t", tc.expectRotate)
+			}
+			if isComputed(logShippingTokenPreviousTokenId) != (tc.expectRotate || tc.expectRetirement) {
+				t.Errorf("expected previous token change to be %t", tc.expectRotate || tc.expectRetirement)
+			}
+		})
+	}
+}
+
 func resourceTestLogShippingToken(name string, path string) string {
 	content, err := os.ReadFile(fmt.Sprintf("testdata/fixtures/%s.tf", path))
 	if err != nil {
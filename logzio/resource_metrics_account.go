@@ -21,6 +21,7 @@ const (
 	metricsAccountToken              string = "account_token"
 	metricsAccountPlanUts            string = "plan_uts"
 	metricsAccountAuthorizedAccounts string = "authorized_accounts"
+	metricsAccountAuthorizedNames    string = "authorized_account_names"
 
 	metricsAccountRetryAttempts = 8
 )
@@ -68,6 +69,13 @@ func resourceMetricsAccount() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			metricsAccountAuthorizedNames: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -90,6 +98,13 @@ func resourceMetricsAccountCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	namedAccountIds, err := subAccountIdsByNames(m, getMetricsAccountAuthorizedNames(d))
+	if err != nil {
+		return diag.Errorf("could not resolve %s: %v", metricsAccountAuthorizedNames, err)
+	}
+
+	createSubAccount.AuthorizedAccountsIds = mergeIds(createSubAccount.AuthorizedAccountsIds, namedAccountIds)
+
 	metricsAccount, err := MetricsClient.CreateMetricsAccount(createSubAccount)
 	if err != nil {
 		return diag.FromErr(err)
@@ -124,6 +139,17 @@ func resourceMetricsAccountRead(ctx context.Context, d *schema.ResourceData, m i
 
 	}
 
+	authorizedNames := getMetricsAccountAuthorizedNames(d)
+	if len(authorizedNames) > 0 {
+		namedAccountIds, err := subAccountIdsByNames(m, authorizedNames)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("could not resolve %s: %v", metricsAccountAuthorizedNames, err))
+		} else {
+			configuredIds := getCreateMetricsAccountFromSchema(d).AuthorizedAccountsIds
+			metricsAccount.AuthorizedAccountsIds = removeNamedIds(metricsAccount.AuthorizedAccountsIds, namedAccountIds, configuredIds)
+		}
+	}
+
 	setMetricsAccount(d, metricsAccount)
 
 	return nil
@@ -140,6 +166,12 @@ func resourceMetricsAccountUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	updateMetricsAccount := getCreateMetricsAccountFromSchema(d)
+	namedAccountIds, err := subAccountIdsByNames(m, getMetricsAccountAuthorizedNames(d))
+	if err != nil {
+		return diag.Errorf("could not resolve %s: %v", metricsAccountAuthorizedNames, err)
+	}
+
+	updateMetricsAccount.AuthorizedAccountsIds = mergeIds(updateMetricsAccount.AuthorizedAccountsIds, namedAccountIds)
 	err = MetricsClient.UpdateMetricsAccount(id, updateMetricsAccount)
 	if err != nil {
 		return diag.FromErr(err)
@@ -164,6 +196,7 @@ func resourceMetricsAccountUpdate(ctx context.Context, d *schema.ResourceData, m
 					// Check if the update shows on read
 					// if not updated yet - retry
 					MetricsAccountFromSchema := getCreateMetricsAccountFromSchema(d)
+					MetricsAccountFromSchema.AuthorizedAccountsIds = mergeIds(MetricsAccountFromSchema.AuthorizedAccountsIds, namedAccountIds)
 					return !reflect.DeepEqual(MetricsAccountFromSchema, updateMetricsAccount)
 				}
 			}),
@@ -235,3 +268,7 @@ func getCreateMetricsAccountFromSchema(d *schema.ResourceData) metrics_accounts.
 
 	return createMetricsAccount
 }
+
+func getMetricsAccountAuthorizedNames(d *schema.ResourceData) []string {
+	return utils.ParseInterfaceSliceToStringSlice(d.Get(metricsAccountAuthorizedNames).(*schema.Set).List())
+}
@@ -218,3 +218,51 @@ resource "logzio_metrics_account" "test_subaccount" {
 }
 `, email, accountId)
 }
+
+func TestAccLogzioMetricsAccount_AuthorizedAccountNames(t *testing.T) {
+	email := os.Getenv(envLogzioEmail)
+	authorizedAccountName := "test_authorized_by_name_" + getRandomId()
+	resourceName := "logzio_metrics_account.test_metrics_account_by_name"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckEmail(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioMetricsAccountAuthorizedNamesConfig(email, authorizedAccountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "authorized_account_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "authorized_account_names.*", authorizedAccountName),
+					// Accounts referenced by name don't show up in the ids
+					resource.TestCheckResourceAttr(resourceName, "authorized_accounts.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLogzioMetricsAccountAuthorizedNamesConfig(email string, authorizedAccountName string) string {
+	return fmt.Sprintf(`
+resource "logzio_subaccount" "test_authorized_subaccount" {
+  deletion_protection = false
+  email = "%s"
+  account_name = "%s"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_accounts = []
+}
+
+resource "logzio_metrics_account" "test_metrics_account_by_name" {
+  email = "%s"
+  account_name = "%s_metrics"
+  plan_uts = 100
+  authorized_account_names = [
+    logzio_subaccount.test_authorized_subaccount.account_name
+  ]
+}
+`, email, authorizedAccountName, email, authorizedAccountName)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -47,9 +50,16 @@ const (
 	errorMultipleMatchingRules = "found multiple (%d) metrics rollup rules matching the criteria, please specify an id or add more search criteria"
 	errorRollupRuleNotFound    = "could not find metrics rollup rule with id %s"
 
-	metricsRollupRulesRetryAttempts = 8
+	metricsRollupRulesRetryAttempts  = 8
+	metricsRollupRulesSearchPageSize = 100
+
+	metricsRollupRulesImportIdSeparator = ":"
+
+	rollupTemplateMetricNamePlaceholder = "metricName"
 )
 
+var rollupTemplatePlaceholderRegex = regexp.MustCompile(`{{([^{}]*)}}`)
+
 // Returns the metrics rollup rules client with the api token from the provider
 func metricsRollupRulesClient(m interface{}) *metrics_rollup_rules.MetricsRollupRulesClient {
 	var client *metrics_rollup_rules.MetricsRollupRulesClient
@@ -64,9 +74,9 @@ func resourceMetricsRollupRules() *schema.Resource {
 		UpdateContext: resourceMetricsRollupRulesUpdate,
 		DeleteContext: resourceMetricsRollupRulesDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceMetricsRollupRulesImport,
 		},
-		CustomizeDiff: validateRollup,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateRollup},
 		Schema: map[string]*schema.Schema{
 			metricsRollupRulesId: {
 				Type:     schema.TypeString,
@@ -179,39 +189,222 @@ func resourceMetricsRollupRules() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			adoptExisting: adoptExistingSchema(),
 		},
 	}
 }
 
-// validateRollup validates the rollup rule configuration based on metric type and aggregation function
-func validateRollup(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-	metricType := d.Get(metricsRollupRulesMetricType).(string)
-	rollupFunction := d.Get(metricsRollupRulesRollupFunction).(string)
+// validateRollup validates the rollup rule configuration. Each diagnostic points at the offending attribute.
+// Values that are unknown during validation are skipped, and left for the API to validate.
+func validateRollup(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if config.IsNull() || !config.IsKnown() {
+		return
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, validateRollupFunction(config)...)
+	resp.Diagnostics = append(resp.Diagnostics, validateRollupLabels(config)...)
+	resp.Diagnostics = append(resp.Diagnostics, validateRollupNewMetricNameTemplate(config)...)
+	resp.Diagnostics = append(resp.Diagnostics, validateRollupFilterRegex(config)...)
+}
+
+// validateRollupFunction validates the rollup function based on the metric type
+func validateRollupFunction(config cty.Value) diag.Diagnostics {
+	metricType, ok := ctyKnownString(config.GetAttr(metricsRollupRulesMetricType))
+	if !ok {
+		return nil
+	}
+
+	rollupFunctionValue := config.GetAttr(metricsRollupRulesRollupFunction)
+	if !rollupFunctionValue.IsKnown() {
+		return nil
+	}
+	rollupFunction, _ := ctyKnownString(rollupFunctionValue)
+	path := cty.GetAttrPath(metricsRollupRulesRollupFunction)
 
 	switch metricType {
-	case string(metrics_rollup_rules.MetricTypeGauge):
-		if rollupFunction == "" {
-			return fmt.Errorf("rollup_function must be set for GAUGE metrics")
-		}
-	case string(metrics_rollup_rules.MetricTypeMeasurement):
+	case string(metrics_rollup_rules.MetricTypeGauge),
+		string(metrics_rollup_rules.MetricTypeMeasurement),
+		string(metrics_rollup_rules.MetricTypeCounter),
+		string(metrics_rollup_rules.MetricTypeDeltaCounter),
+		string(metrics_rollup_rules.MetricTypeCumulativeCounter):
 		if rollupFunction == "" {
-			return fmt.Errorf("rollup_function must be set for MEASUREMENT metrics")
+			return attributeErrorDiagnostics(path, fmt.Sprintf("rollup_function must be set for %s metrics", metricType), "")
 		}
+	}
+
+	switch metricType {
 	case string(metrics_rollup_rules.MetricTypeCounter),
 		string(metrics_rollup_rules.MetricTypeDeltaCounter),
 		string(metrics_rollup_rules.MetricTypeCumulativeCounter):
-		if rollupFunction == "" {
-			return fmt.Errorf("rollup_function must be set for %s metrics", metricType)
-		}
 		if rollupFunction != string(metrics_rollup_rules.AggSum) {
-			return fmt.Errorf("for %s metrics, rollup_function must be SUM", metricType)
+			return attributeErrorDiagnostics(path, fmt.Sprintf("for %s metrics, rollup_function must be SUM", metricType), "")
 		}
 	}
+
 	return nil
 }
 
+// validateRollupLabels validates the labels against the labels elimination method
+func validateRollupLabels(config cty.Value) diag.Diagnostics {
+	labels := config.GetAttr(metricsRollupRulesLabels)
+	if labels.IsNull() || !labels.IsKnown() {
+		return nil
+	}
+
+	method, _ := ctyKnownString(config.GetAttr(metricsRollupRulesLabelsEliminationMethod))
+	var diags diag.Diagnostics
+	seen := make(map[string]bool)
+	i := 0
+	for it := labels.ElementIterator(); it.Next(); i++ {
+		_, value := it.Element()
+		label, ok := ctyKnownString(value)
+		if !ok {
+			continue
+		}
+
+		path := cty.GetAttrPath(metricsRollupRulesLabels).IndexInt(i)
+		switch {
+		case strings.TrimSpace(label) == "":
+			diags = append(diags, attributeErrorDiagnostics(path, "labels must not contain empty label names", "")...)
+		case seen[label]:
+			diags = append(diags, attributeErrorDiagnostics(path, fmt.Sprintf("label %q appears more than once in labels", label), "")...)
+		case label == metricNameLabel && method == string(metrics_rollup_rules.LabelsExcludeBy):
+			diags = append(diags, attributeErrorDiagnostics(path,
+				fmt.Sprintf("%s can't be excluded with %s", metricNameLabel, metrics_rollup_rules.LabelsExcludeBy),
+				"The metric name is always kept. To roll up several metrics into one, use new_metric_name_template.",
+			)...)
+		}
+		seen[label] = true
+	}
+
+	return diags
+}
+
+// validateRollupNewMetricNameTemplate validates the placeholders in the new metric name template,
+// and that dropping the original metric leaves a rollup with a different name
+func validateRollupNewMetricNameTemplate(config cty.Value) diag.Diagnostics {
+	templateValue := config.GetAttr(metricsRollupRulesNewMetricNameTemplate)
+	if !templateValue.IsKnown() {
+		return nil
+	}
+
+	template, _ := ctyKnownString(templateValue)
+	path := cty.GetAttrPath(metricsRollupRulesNewMetricNameTemplate)
+	var diags diag.Diagnostics
+	if template != "" {
+		for _, placeholder := range rollupTemplatePlaceholderRegex.FindAllStringSubmatch(template, -1) {
+			if strings.TrimSpace(placeholder[1]) != rollupTemplateMetricNamePlaceholder {
+				diags = append(diags, attributeErrorDiagnostics(path,
+					fmt.Sprintf("unknown placeholder %s in new_metric_name_template", placeholder[0]),
+					fmt.Sprintf("The only supported placeholder is {{%s}}.", rollupTemplateMetricNamePlaceholder))...)
+			}
+		}
+
+		unmatched := rollupTemplatePlaceholderRegex.ReplaceAllString(template, "")
+		if strings.Contains(unmatched, "{{") || strings.Contains(unmatched, "}}") {
+			diags = append(diags, attributeErrorDiagnostics(path, "new_metric_name_template has an unclosed placeholder", "")...)
+		}
+	}
+
+	dropOriginal := config.GetAttr(metricsRollupRulesDropOriginalMetric)
+	if dropOriginal.IsNull() || !dropOriginal.IsKnown() || dropOriginal.False() {
+		return diags
+	}
+
+	// A template that is only the metric name placeholder, or the metric name itself, keeps the original name
+	metricName, _ := ctyKnownString(config.GetAttr(metricsRollupRulesMetricName))
+	onlyPlaceholder := rollupTemplatePlaceholderRegex.ReplaceAllString(strings.TrimSpace(template), "") == ""
+	if template == "" || onlyPlaceholder || template == metricName {
+		diags = append(diags, attributeErrorDiagnostics(cty.GetAttrPath(metricsRollupRulesDropOriginalMetric),
+			"drop_original_metric requires a new_metric_name_template that results in a different metric name",
+			"Without a different name, the rollup metric would be dropped together with the original metric.")...)
+	}
+
+	return diags
+}
+
+// validateRollupFilterRegex validates the regex syntax of REGEX_MATCH and REGEX_NO_MATCH filter expressions
+func validateRollupFilterRegex(config cty.Value) diag.Diagnostics {
+	filters := config.GetAttr(metricsRollupRulesFilter)
+	if filters.IsNull() || !filters.IsKnown() || filters.LengthInt() == 0 {
+		return nil
+	}
+
+	filter := filters.Index(cty.NumberIntVal(0))
+	if filter.IsNull() || !filter.IsKnown() {
+		return nil
+	}
+
+	expressions := filter.GetAttr(metricsRollupRulesFilterExpression)
+	if expressions.IsNull() || !expressions.IsKnown() {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	i := 0
+	for it := expressions.ElementIterator(); it.Next(); i++ {
+		_, expression := it.Element()
+		if expression.IsNull() || !expression.IsKnown() {
+			continue
+		}
+
+		comparison, _ := ctyKnownString(expression.GetAttr(metricsRollupRulesFilterComparison))
+		if comparison != comparisonRegexMatch && comparison != comparisonRegexNoMatch {
+			continue
+		}
+
+		value, ok := ctyKnownString(expression.GetAttr(metricsRollupRulesFilterValue))
+		if !ok {
+			continue
+		}
+
+		if _, err := regexp.Compile(value); err != nil {
+			path := cty.GetAttrPath(metricsRollupRulesFilter).IndexInt(0).
+				GetAttr(metricsRollupRulesFilterExpression).IndexInt(i).
+				GetAttr(metricsRollupRulesFilterValue)
+			diags = append(diags, attributeErrorDiagnostics(path, fmt.Sprintf("invalid regex for %s", comparison), err.Error())...)
+		}
+	}
+
+	return diags
+}
+
+// ctyKnownString returns the string value of a known, non-null cty value
+func ctyKnownString(value cty.Value) (string, bool) {
+	if value.IsNull() || !value.IsKnown() {
+		return "", false
+	}
+
+	return value.AsString(), true
+}
+
+// attributeErrorDiagnostics returns an error diagnostic for the attribute in path
+func attributeErrorDiagnostics(path cty.Path, summary string, detail string) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path,
+		},
+	}
+}
+
 // resourceMetricsRollupRulesCreate creates a new metrics rollup rule in logzio
 func resourceMetricsRollupRulesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if shouldAdoptExisting(d, m) {
+		id, err := findMetricsRollupRuleIdToAdopt(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if id != "" {
+			d.SetId(id)
+			return resourceMetricsRollupRulesUpdate(ctx, d, m)
+		}
+	}
+
 	createRollupRule := createCreateUpdateMetricsRollupRuleFromSchema(d)
 
 	rollupRule, err := metricsRollupRulesClient(m).CreateRollupRule(createRollupRule)
@@ -273,6 +466,108 @@ func resourceMetricsRollupRulesDelete(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
+// findMetricsRollupRuleIdToAdopt searches the account for a metrics rollup rule with the same name,
+// or with the same metric_name if the rule has no name.
+func findMetricsRollupRuleIdToAdopt(ctx context.Context, d *schema.ResourceData, m interface{}) (string, error) {
+	req := metrics_rollup_rules.SearchRollupRulesRequest{
+		Filter:     &metrics_rollup_rules.SearchFilter{AccountIds: []int64{int64(d.Get(metricsRollupRulesAccountId).(int))}},
+		Pagination: &metrics_rollup_rules.Pagination{PageNumber: 1, PageSize: metricsRollupRulesSearchPageSize},
+	}
+
+	key, value := metricsRollupRulesName, d.Get(metricsRollupRulesName).(string)
+	if value != "" {
+		req.Filter.SearchTerm = value
+	} else {
+		key, value = metricsRollupRulesMetricName, d.Get(metricsRollupRulesMetricName).(string)
+		if value == "" {
+			tflog.Info(ctx, fmt.Sprintf("neither %s nor %s is set, the metrics rollup rule can't be adopted", metricsRollupRulesName, metricsRollupRulesMetricName))
+			return "", nil
+		}
+		req.Filter.MetricNames = []string{value}
+	}
+
+	matchingIds, err := searchMetricsRollupRuleIds(metricsRollupRulesClient(m), req, func(rule metrics_rollup_rules.RollupRule) bool {
+		return (key == metricsRollupRulesName && rule.Name == value) || (key == metricsRollupRulesMetricName && rule.Name == "" && rule.MetricName == value)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return findIdToAdopt(ctx, "metrics rollup rule", key, value, matchingIds)
+}
+
+// searchMetricsRollupRuleIds pages through the search results and returns the ids of the rules that aren't deleted and match
+func searchMetricsRollupRuleIds(client *metrics_rollup_rules.MetricsRollupRulesClient, req metrics_rollup_rules.SearchRollupRulesRequest, match func(rule metrics_rollup_rules.RollupRule) bool) ([]string, error) {
+	var matchingIds []string
+	for {
+		results, err := client.SearchRollupRules(req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range results {
+			if !rule.IsDeleted && match(rule) {
+				matchingIds = append(matchingIds, rule.Id)
+			}
+		}
+
+		if len(results) < metricsRollupRulesSearchPageSize {
+			break
+		}
+		req.Pagination.PageNumber++
+	}
+
+	return matchingIds, nil
+}
+
+// resourceMetricsRollupRulesImport imports a metrics rollup rule by its id, or by account_id:name or account_id:metric_name.
+// Rules are matched by name first, and by metric name only if no rule has that name.
+func resourceMetricsRollupRulesImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	accountIdStr, value, found := strings.Cut(d.Id(), metricsRollupRulesImportIdSeparator)
+	if !found {
+		return schema.ImportStatePassthroughContext(ctx, d, m)
+	}
+
+	accountId, err := strconv.ParseInt(accountIdStr, 10, 64)
+	if err != nil || value == "" {
+		return nil, fmt.Errorf("invalid import id %s, expected id, account_id:name or account_id:metric_name", d.Id())
+	}
+
+	client := metricsRollupRulesClient(m)
+	req := metrics_rollup_rules.SearchRollupRulesRequest{
+		Filter:     &metrics_rollup_rules.SearchFilter{AccountIds: []int64{accountId}, SearchTerm: value},
+		Pagination: &metrics_rollup_rules.Pagination{PageNumber: 1, PageSize: metricsRollupRulesSearchPageSize},
+	}
+	matchingIds, err := searchMetricsRollupRuleIds(client, req, func(rule metrics_rollup_rules.RollupRule) bool {
+		return rule.AccountId == accountId && rule.Name == value
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchingIds) == 0 {
+		req.Filter = &metrics_rollup_rules.SearchFilter{AccountIds: []int64{accountId}, MetricNames: []string{value}}
+		req.Pagination.PageNumber = 1
+		matchingIds, err = searchMetricsRollupRuleIds(client, req, func(rule metrics_rollup_rules.RollupRule) bool {
+			return rule.AccountId == accountId && rule.MetricName == value
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch len(matchingIds) {
+	case 0:
+		return nil, fmt.Errorf("couldn't find metrics rollup rule with name or metric name %s in account %d", value, accountId)
+	case 1:
+		tflog.Info(ctx, fmt.Sprintf("importing metrics rollup rule %s for %s", matchingIds[0], d.Id()))
+		d.SetId(matchingIds[0])
+		return []*schema.ResourceData{d}, nil
+	default:
+		return nil, fmt.Errorf(errorMultipleMatchingRules+" (ids: %v)", len(matchingIds), matchingIds)
+	}
+}
+
 // createCreateUpdateMetricsRollupRuleFromSchema creates a CreateUpdateRollupRule object from the schema
 func createCreateUpdateMetricsRollupRuleFromSchema(d *schema.ResourceData) metrics_rollup_rules.CreateUpdateRollupRule {
 	accountId := int64(d.Get(metricsRollupRulesAccountId).(int))
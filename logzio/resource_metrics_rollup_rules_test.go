@@ -1,13 +1,16 @@
 package logzio
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 )
 
@@ -25,6 +28,8 @@ const (
 	metricsRollupRulesResourceCreateMeasurementP99               = "create_metrics_rollup_rules_measurement_p99"
 	metricsRollupRulesResourceCreateCounterWithRollupFunction    = "create_metrics_rollup_rules_counter_with_rollup_function"
 	metricsRollupRulesResourceCreateCounterMissingRollupFunction = "create_metrics_rollup_rules_counter_missing_rollup_function"
+	metricsRollupRulesResourceCreateInvalidFilterRegex           = "create_metrics_rollup_rules_invalid_filter_regex"
+	metricsRollupRulesResourceCreateDropOriginalWithoutTemplate  = "create_metrics_rollup_rules_drop_original_without_template"
 )
 
 func TestAccLogzioMetricsRollupRules_CreateSimple(t *testing.T) {
@@ -63,6 +68,41 @@ func TestAccLogzioMetricsRollupRules_CreateSimple(t *testing.T) {
 	})
 }
 
+func TestAccLogzioMetricsRollupRules_ImportByMetricName(t *testing.T) {
+	resourceName := "test_import_metrics_rollup_rules_by_metric_name"
+	resourceFullName := "logzio_metrics_rollup_rules." + resourceName
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: resourceTestMetricsRollupRules(resourceName, metricsRollupRulesResourceCreateSimple, accountId),
+			},
+			{
+				Config:            resourceTestMetricsRollupRules(resourceName, metricsRollupRulesResourceCreateSimple, accountId),
+				ResourceName:      resourceFullName,
+				ImportState:       true,
+				ImportStateId:     accountId + ":cpu_usage",
+				ImportStateVerify: true,
+			},
+			{
+				Config:        resourceTestMetricsRollupRules(resourceName, metricsRollupRulesResourceCreateSimple, accountId),
+				ResourceName:  resourceFullName,
+				ImportState:   true,
+				ImportStateId: accountId + ":cpu_usage_missing",
+				ExpectError:   regexp.MustCompile("couldn't find metrics rollup rule with name or metric name cpu_usage_missing"),
+			},
+		},
+	})
+}
+
 func TestAccLogzioMetricsRollupRules_CreateComplex(t *testing.T) {
 	resourceName := "test_create_metrics_rollup_rules_complex"
 	resourceFullName := "logzio_metrics_rollup_rules." + resourceName
@@ -351,6 +391,166 @@ func TestAccLogzioMetricsRollupRules_CreateCounterMissingRollupFunction(t *testi
 	})
 }
 
+func TestAccLogzioMetricsRollupRules_CreateInvalidFilterRegex(t *testing.T) {
+	resourceName := "test_create_metrics_rollup_rules_invalid_filter_regex"
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      resourceTestMetricsRollupRules(resourceName, metricsRollupRulesResourceCreateInvalidFilterRegex, accountId),
+				ExpectError: regexp.MustCompile("invalid regex for REGEX_MATCH"),
+			},
+		},
+	})
+}
+
+func TestAccLogzioMetricsRollupRules_CreateDropOriginalWithoutTemplate(t *testing.T) {
+	resourceName := "test_create_metrics_rollup_rules_drop_original_without_template"
+	accountId := os.Getenv(envLogzioMetricsAccountId)
+
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckMetricsAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      resourceTestMetricsRollupRules(resourceName, metricsRollupRulesResourceCreateDropOriginalWithoutTemplate, accountId),
+				ExpectError: regexp.MustCompile("drop_original_metric requires a new_metric_name_template"),
+			},
+		},
+	})
+}
+
+func TestMetricsRollupRules_ValidateRollup(t *testing.T) {
+	expression := func(comparison, name, value string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			metricsRollupRulesFilterComparison: cty.StringVal(comparison),
+			metricsRollupRulesFilterName:       cty.StringVal(name),
+			metricsRollupRulesFilterValue:      cty.StringVal(value),
+		})
+	}
+
+	valid := map[string]cty.Value{
+		metricsRollupRulesMetricName:              cty.StringVal("http_requests_total"),
+		metricsRollupRulesMetricType:              cty.StringVal("COUNTER"),
+		metricsRollupRulesRollupFunction:          cty.StringVal("SUM"),
+		metricsRollupRulesLabelsEliminationMethod: cty.StringVal("EXCLUDE_BY"),
+		metricsRollupRulesLabels:                  cty.ListVal([]cty.Value{cty.StringVal("path")}),
+	}
+
+	cases := []struct {
+		name          string
+		values        map[string]cty.Value
+		expectedPath  cty.Path
+		expectedError string
+	}{
+		{
+			name:   "valid",
+			values: map[string]cty.Value{},
+		},
+		{
+			name:          "counter without sum",
+			values:        map[string]cty.Value{metricsRollupRulesRollupFunction: cty.StringVal("MAX")},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesRollupFunction),
+			expectedError: "for COUNTER metrics, rollup_function must be SUM",
+		},
+		{
+			name:          "duplicate labels",
+			values:        map[string]cty.Value{metricsRollupRulesLabels: cty.ListVal([]cty.Value{cty.StringVal("path"), cty.StringVal("path")})},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesLabels).IndexInt(1),
+			expectedError: "appears more than once",
+		},
+		{
+			name:          "exclude metric name",
+			values:        map[string]cty.Value{metricsRollupRulesLabels: cty.ListVal([]cty.Value{cty.StringVal(metricNameLabel)})},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesLabels).IndexInt(0),
+			expectedError: "can't be excluded",
+		},
+		{
+			name:          "unknown placeholder",
+			values:        map[string]cty.Value{metricsRollupRulesNewMetricNameTemplate: cty.StringVal("rollup.{{metric}}")},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesNewMetricNameTemplate),
+			expectedError: "unknown placeholder {{metric}}",
+		},
+		{
+			name:          "unclosed placeholder",
+			values:        map[string]cty.Value{metricsRollupRulesNewMetricNameTemplate: cty.StringVal("rollup.{{metricName")},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesNewMetricNameTemplate),
+			expectedError: "unclosed placeholder",
+		},
+		{
+			name: "drop original with the original name",
+			values: map[string]cty.Value{
+				metricsRollupRulesNewMetricNameTemplate: cty.StringVal("{{ metricName }}"),
+				metricsRollupRulesDropOriginalMetric:    cty.True,
+			},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesDropOriginalMetric),
+			expectedError: "drop_original_metric requires",
+		},
+		{
+			name: "invalid filter regex",
+			values: map[string]cty.Value{
+				metricsRollupRulesMetricName: cty.NullVal(cty.String),
+				metricsRollupRulesFilter: cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					metricsRollupRulesFilterExpression: cty.ListVal([]cty.Value{
+						expression(comparisonEq, "service", "("),
+						expression(comparisonRegexNoMatch, "region", "us-(east"),
+					}),
+				})}),
+			},
+			expectedPath:  cty.GetAttrPath(metricsRollupRulesFilter).IndexInt(0).GetAttr(metricsRollupRulesFilterExpression).IndexInt(1).GetAttr(metricsRollupRulesFilterValue),
+			expectedError: "invalid regex for REGEX_NO_MATCH",
+		},
+	}
+
+	configType := resourceMetricsRollupRules().CoreConfigSchema().ImpliedType()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes := make(map[string]cty.Value)
+			for name, attributeType := range configType.AttributeTypes() {
+				attributes[name] = cty.NullVal(attributeType)
+			}
+			for name, value := range valid {
+				attributes[name] = value
+			}
+			for name, value := range tc.values {
+				attributes[name] = value
+			}
+
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateRollup(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: cty.ObjectVal(attributes)}, resp)
+			if tc.expectedError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("expected no errors, got %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(resp.Diagnostics) != 1 {
+				t.Fatalf("expected a single diagnostic, got %v", resp.Diagnostics)
+			}
+			if !regexp.MustCompile(regexp.QuoteMeta(tc.expectedError)).MatchString(resp.Diagnostics[0].Summary) {
+				t.Errorf("expected error %q, got %q", tc.expectedError, resp.Diagnostics[0].Summary)
+			}
+			if !resp.Diagnostics[0].AttributePath.Equals(tc.expectedPath) {
+				t.Errorf("expected the error to point at %v, got %v", tc.expectedPath, resp.Diagnostics[0].AttributePath)
+			}
+		})
+	}
+}
+
 func TestAccLogzioMetricsRollupRules_CreateMeasurementWithP99(t *testing.T) {
 	resourceName := "test_create_metrics_rollup_rules_measurement_p99"
 	accountId := os.Getenv(envLogzioMetricsAccountId)
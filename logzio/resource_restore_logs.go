@@ -2,6 +2,7 @@ package logzio
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -9,23 +10,27 @@ import (
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
-	restoreLogsId               = "restore_operation_id"
-	restoreLogsAccountName      = "account_name"
-	restoreLogsUsername         = "username"
-	restoreLogsStartTime        = "start_time"
-	restoreLogsEndTime          = "end_time"
-	restoreLogsAccountId        = "account_id"
-	restoreLogsRestoredVolumeGb = "restored_volume_gb"
-	restoreLogsStatus           = "status"
-	restoreLogsCreatedAt        = "created_at"
-	restoreLogsStartedAt        = "started_at"
-	restoreLogsFinishedAt       = "finished_at"
-	restoreLogsExpiresAt        = "expires_at"
+	restoreLogsId                = "restore_operation_id"
+	restoreLogsAccountName       = "account_name"
+	restoreLogsUsername          = "username"
+	restoreLogsStartTime         = "start_time"
+	restoreLogsEndTime           = "end_time"
+	restoreLogsAccountId         = "account_id"
+	restoreLogsRestoredVolumeGb  = "restored_volume_gb"
+	restoreLogsStatus            = "status"
+	restoreLogsCreatedAt         = "created_at"
+	restoreLogsStartedAt         = "started_at"
+	restoreLogsFinishedAt        = "finished_at"
+	restoreLogsExpiresAt         = "expires_at"
+	restoreLogsWaitForCompletion = "wait_for_completion"
+	restoreLogsRenewOnExpiry     = "renew_on_expiry"
 
 	restoreLogsRetryAttempts = 8
+	restoreLogsPollInterval  = 30 * time.Second
 )
 
 // restoreLogsClient returns the restore logs client with the api token from the provider
@@ -39,6 +44,7 @@ func resourceRestoreLogs() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRestoreLogsCreate,
 		ReadContext:   resourceRestoreLogsRead,
+		UpdateContext: resourceRestoreLogsUpdate,
 		DeleteContext: resourceRestoreLogsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -96,6 +102,19 @@ func resourceRestoreLogs() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			restoreLogsWaitForCompletion: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			restoreLogsRenewOnExpiry: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
 		},
 	}
 }
@@ -109,6 +128,18 @@ func resourceRestoreLogsCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	d.SetId(strconv.FormatInt(int64(restore.Id), 10))
 	d.Set(restoreLogsUsername, initiateRestore.UserName)
+
+	if d.Get(restoreLogsWaitForCompletion).(bool) {
+		restore, err = waitForRestoreCompletion(ctx, m, restore.Id, d.Timeout(schema.TimeoutCreate))
+		if restore != nil {
+			setRestore(d, restore)
+		}
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceRestoreLogsRead(ctx, d, m)
 }
 
@@ -123,6 +154,10 @@ func resourceRestoreLogsRead(ctx context.Context, d *schema.ResourceData, m inte
 		tflog.Error(ctx, err.Error())
 		if strings.Contains(err.Error(), "missing restore") {
 			// If we were not able to find the resource - delete from state
+			if d.Get(restoreLogsRenewOnExpiry).(bool) {
+				return renewRestoreDiagnostics(d, "was deleted")
+			}
+
 			d.SetId("")
 			return diag.Diagnostics{}
 		} else {
@@ -131,10 +166,36 @@ func resourceRestoreLogsRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	}
 
+	if d.Get(restoreLogsRenewOnExpiry).(bool) &&
+		(restore.Status == restore_logs.RestoreStatusExpired || restore.Status == restore_logs.RestoreStatusDeleted) {
+		return renewRestoreDiagnostics(d, fmt.Sprintf("has status %s", restore.Status))
+	}
+
 	setRestore(d, restore)
 	return nil
 }
 
+// renewRestoreDiagnostics removes the restore operation from the state, so a new one with the same
+// arguments will be planned, and returns a warning explaining why
+func renewRestoreDiagnostics(d *schema.ResourceData, reason string) diag.Diagnostics {
+	restoreId := d.Id()
+	d.SetId("")
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Restore operation %s %s and will be renewed", restoreId, reason),
+			Detail: fmt.Sprintf("%s is set, so the restore operation was removed from the state. "+
+				"A new restore operation for account %q with the same start_time and end_time will be created on the next apply.",
+				restoreLogsRenewOnExpiry, d.Get(restoreLogsAccountName).(string)),
+		},
+	}
+}
+
+// resourceRestoreLogsUpdate only handles attributes that don't affect the restore operation itself
+func resourceRestoreLogsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceRestoreLogsRead(ctx, d, m)
+}
+
 func resourceRestoreLogsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	id, err := utils.IdFromResourceData(d)
 	if err != nil {
@@ -172,3 +233,37 @@ func setRestore(d *schema.ResourceData, restore *restore_logs.RestoreOperation)
 	d.Set(restoreLogsFinishedAt, restore.FinishedAt)
 	d.Set(restoreLogsExpiresAt, restore.ExpiresAt)
 }
+
+// waitForRestoreCompletion polls the restore operation until it is active, failed or the timeout is reached.
+// Returns the last restore operation that was read, and an error if the restore did not become active.
+func waitForRestoreCompletion(ctx context.Context, m interface{}, restoreId int32, timeout time.Duration) (*restore_logs.RestoreOperation, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var restore *restore_logs.RestoreOperation
+	for {
+		current, err := restoreLogsClient(m).GetRestoreOperation(restoreId)
+		if err != nil {
+			return restore, fmt.Errorf("could not get status of restore operation %d: %v", restoreId, err)
+		}
+
+		restore = current
+		tflog.Debug(ctx, fmt.Sprintf("restore operation %d status: %s", restoreId, restore.Status))
+		switch restore.Status {
+		case restore_logs.RestoreStatusActive:
+			return restore, nil
+		case restore_logs.RestoreStatusFailed,
+			restore_logs.RestoreStatusAborted,
+			restore_logs.RestoreStatusLimitExceeded,
+			restore_logs.RestoreStatusDeleted,
+			restore_logs.RestoreStatusExpired:
+			return restore, fmt.Errorf("restore operation %d did not complete, status: %s", restoreId, restore.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return restore, fmt.Errorf("timed out after %s waiting for restore operation %d to complete, last status: %s", timeout, restoreId, restore.Status)
+		case <-time.After(restoreLogsPollInterval):
+		}
+	}
+}
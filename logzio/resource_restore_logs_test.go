@@ -2,10 +2,13 @@ package logzio
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -57,6 +60,35 @@ func TestAccLogzioRestoreLogs_InitiateRestore(t *testing.T) {
 	})
 }
 
+func TestAccLogzioRestoreLogs_InitiateRestoreWaitForCompletion(t *testing.T) {
+	path := os.Getenv(envLogzioS3Path)
+	arn := os.Getenv(envLogzioAwsArn)
+	archiveName := "archive_for_restore_wait"
+	restoreName := "tf_test_restore_wait"
+	fullRestoreName := "logzio_restore_logs." + restoreName
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:  getConfigTestArchiveS3Iam(archiveName, path, arn),
+				Destroy: false,
+			},
+			{
+				Config: getConfigTestArchiveS3Iam(archiveName, path, arn) +
+					getConfigTestRestoreWaitForCompletion(restoreName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fullRestoreName, restoreLogsId),
+					resource.TestCheckResourceAttr(fullRestoreName, restoreLogsStatus, "ACTIVE"),
+					resource.TestCheckResourceAttrSet(fullRestoreName, restoreLogsExpiresAt),
+				),
+			},
+		},
+	})
+}
+
 func TestAccLogzioRestoreLogs_InitiateRestoreEmptyStartTime(t *testing.T) {
 	restoreName := "tf_test_empty_start_time"
 	defer utils.SleepAfterTest()
@@ -105,6 +137,30 @@ func TestAccLogzioRestoreLogs_InitiateRestoreEmptyUsername(t *testing.T) {
 	})
 }
 
+func TestRestoreLogs_RenewRestoreDiagnostics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRestoreLogs().Schema, map[string]interface{}{
+		restoreLogsAccountName:   "tf-test-renew",
+		restoreLogsUsername:      "test@logz.io",
+		restoreLogsStartTime:     1635134987,
+		restoreLogsEndTime:       1635145789,
+		restoreLogsRenewOnExpiry: true,
+	})
+	d.SetId("1234")
+
+	diags := renewRestoreDiagnostics(d, "has status EXPIRED")
+	if d.Id() != "" {
+		t.Fatalf("expected restore to be removed from state, got id %s", d.Id())
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	if !strings.Contains(diags[0].Summary, "1234") || !strings.Contains(diags[0].Detail, "tf-test-renew") {
+		t.Errorf("expected warning to mention the restore id and account name, got %q: %q", diags[0].Summary, diags[0].Detail)
+	}
+}
+
 func getConfigTestRestore(name string) string {
 	now := time.Now()
 	accountName := fmt.Sprintf("tf-test-%s", now.Format("2006-01-02,15:04:05"))
@@ -119,6 +175,24 @@ func getConfigTestRestore(name string) string {
 `, name, accountName, username, hourAgo.Unix(), now.Unix())
 }
 
+func getConfigTestRestoreWaitForCompletion(name string) string {
+	now := time.Now()
+	accountName := fmt.Sprintf("tf-test-wait-%s", now.Format("2006-01-02,15:04:05"))
+	hourAgo := now.Add(-time.Hour)
+	username := os.Getenv(envLogzioEmail)
+	return fmt.Sprintf(`resource "logzio_restore_logs" "%s" {
+ account_name = "%s"
+ username = "%s"
+ start_time = %d
+ end_time = %d
+ wait_for_completion = true
+ timeouts {
+   create = "2h"
+ }
+}
+`, name, accountName, username, hourAgo.Unix(), now.Unix())
+}
+
 func getConfigTestRestoreEmptyStartTime(name string) string {
 	now := time.Now()
 	accountName := fmt.Sprintf("tf-test-%s", now.Format("2006-01-02,15:04:05"))
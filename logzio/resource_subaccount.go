@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/logzio/logzio_terraform_client/archive_logs"
 	"github.com/logzio/logzio_terraform_client/sub_accounts"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 )
@@ -30,6 +32,7 @@ const (
 	subAccountAccessible                            string = "accessible"
 	subAccountDocSizeSetting                        string = "doc_size_setting"
 	subAccountSharingObjectsAccounts                string = "sharing_objects_accounts"
+	subAccountSharingObjectsAccountNames            string = "sharing_objects_account_names"
 	subAccountUtilizationSettingsFrequencyMinutes   string = "frequency_minutes"
 	subAccountUtilizationSettingsUtilizationEnabled string = "utilization_enabled"
 	subAccountsSnapSearchRetentionDays              string = "snap_search_retention_days"
@@ -38,6 +41,9 @@ const (
 	subAccountsTotalTimeBasedDailyGb                string = "total_time_based_daily_gb"
 	subAccountIsOwner                               string = "is_owner"
 	subAccountSoftLimitGB                           string = "soft_limit_gb"
+	subAccountDeletionProtection                    string = "deletion_protection"
+	subAccountArchiveBeforeDelete                   string = "archive_before_delete"
+	subAccountArchiveApiToken                       string = "archive_api_token"
 
 	delayGetSubAccount      = 2 * time.Second
 	subAccountRetryAttempts = 8
@@ -51,8 +57,9 @@ func resourceSubAccount() *schema.Resource {
 		UpdateContext: resourceSubAccountUpdate,
 		DeleteContext: resourceSubAccountDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSubAccountImport,
 		},
+		CustomizeDiff: customizeSubAccountArchiveBeforeDelete,
 
 		Schema: map[string]*schema.Schema{
 			subAccountId: {
@@ -106,6 +113,13 @@ func resourceSubAccount() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			subAccountSharingObjectsAccountNames: {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			subAccountDocSizeSetting: {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -143,6 +157,21 @@ func resourceSubAccount() *schema.Resource {
 				Type:     schema.TypeFloat,
 				Optional: true,
 			},
+			subAccountDeletionProtection: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			subAccountArchiveBeforeDelete: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			subAccountArchiveApiToken: {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
 		},
 	}
 }
@@ -155,6 +184,12 @@ func subAccountClient(m interface{}) *sub_accounts.SubAccountClient {
 
 func resourceSubAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	createSubAccount := getCreateSubAccountFromSchema(d)
+	namedAccountIds, err := subAccountIdsByNames(m, getSubAccountSharingObjectsAccountNames(d))
+	if err != nil {
+		return diag.Errorf("could not resolve %s: %v", subAccountSharingObjectsAccountNames, err)
+	}
+
+	createSubAccount.SharingObjectsAccounts = mergeIds(createSubAccount.SharingObjectsAccounts, namedAccountIds)
 	subAccount, err := subAccountClient(m).CreateSubAccount(createSubAccount)
 	if err != nil {
 		return diag.FromErr(err)
@@ -184,7 +219,9 @@ func resourceSubAccountRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	}
 
+	configuredIds := getCreateSubAccountFromSchema(d).SharingObjectsAccounts
 	setSubAccount(d, subAccount)
+	setSubAccountSharingObjectsAccountNames(d, subAccount, configuredIds)
 	// Sub accounts created before v1.2.4 had no account_id, account_token attributes.
 	// These lines add those attributes to already existing resources on Read
 	err = setTokenAndId(d, m, id)
@@ -201,7 +238,18 @@ func resourceSubAccountUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	// The deletion settings only exist in terraform, there's nothing to update in Logz.io
+	if !d.HasChangesExcept(subAccountDeletionProtection, subAccountArchiveBeforeDelete, subAccountArchiveApiToken) {
+		return resourceSubAccountRead(ctx, d, m)
+	}
+
 	updateSubAccount := getCreateSubAccountFromSchema(d)
+	namedAccountIds, err := subAccountIdsByNames(m, getSubAccountSharingObjectsAccountNames(d))
+	if err != nil {
+		return diag.Errorf("could not resolve %s: %v", subAccountSharingObjectsAccountNames, err)
+	}
+
+	updateSubAccount.SharingObjectsAccounts = mergeIds(updateSubAccount.SharingObjectsAccounts, namedAccountIds)
 	err = subAccountClient(m).UpdateSubAccount(id, updateSubAccount)
 	if err != nil {
 		return diag.FromErr(err)
@@ -226,6 +274,7 @@ func resourceSubAccountUpdate(ctx context.Context, d *schema.ResourceData, m int
 					// Check if the update shows on read
 					// if not updated yet - retry
 					subAccountFromSchema := getCreateSubAccountFromSchema(d)
+					subAccountFromSchema.SharingObjectsAccounts = mergeIds(subAccountFromSchema.SharingObjectsAccounts, namedAccountIds)
 					return !reflect.DeepEqual(subAccountFromSchema, updateSubAccount)
 				}
 			}),
@@ -247,6 +296,22 @@ func resourceSubAccountDelete(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	// Deleting a sub account deletes all of its data, so it's blocked unless explicitly allowed
+	if d.Get(subAccountDeletionProtection).(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("cannot delete sub account %d, deletion_protection is enabled", id),
+			Detail: "Deleting a sub account deletes all of its data. To delete it, set deletion_protection = false and apply, " +
+				"then destroy the resource or remove it from the configuration.",
+		}}
+	}
+
+	if d.Get(subAccountArchiveBeforeDelete).(bool) {
+		if diags := checkSubAccountArchiveEnabled(ctx, d, m, id); diags.HasError() {
+			return diags
+		}
+	}
+
 	err = subAccountClient(m).DeleteSubAccount(id)
 
 	if err != nil {
@@ -257,6 +322,50 @@ func resourceSubAccountDelete(ctx context.Context, d *schema.ResourceData, m int
 	return nil
 }
 
+func resourceSubAccountImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// The deletion settings aren't returned by the API, so an imported sub account gets the defaults instead of a diff
+	d.Set(subAccountDeletionProtection, true)
+	d.Set(subAccountArchiveBeforeDelete, false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func customizeSubAccountArchiveBeforeDelete(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	// Archives are read with the token's account, so checking with the provider's token would check the wrong account.
+	// A token that's not known yet, for example another resource's attribute, is checked on the next plan
+	if diff.Get(subAccountArchiveBeforeDelete).(bool) && diff.NewValueKnown(subAccountArchiveApiToken) && diff.Get(subAccountArchiveApiToken).(string) == "" {
+		return fmt.Errorf("%s must be set when %s is true", subAccountArchiveApiToken, subAccountArchiveBeforeDelete)
+	}
+
+	return nil
+}
+
+// checkSubAccountArchiveEnabled returns an error diagnostic unless the sub account has an enabled archive, so its data is kept after the delete
+func checkSubAccountArchiveEnabled(ctx context.Context, d *schema.ResourceData, m interface{}, id int64) diag.Diagnostics {
+	client, err := archive_logs.New(d.Get(subAccountArchiveApiToken).(string), m.(Config).baseUrl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	archives, err := client.ListArchiveLog()
+	if err != nil && !strings.Contains(err.Error(), "missing archive") {
+		return diag.FromErr(fmt.Errorf("could not check the archive of sub account %d: %s", id, err.Error()))
+	}
+
+	for _, archive := range archives {
+		if archive.Settings.Enabled {
+			tflog.Info(ctx, fmt.Sprintf("sub account %d is archived to %s (archive id %d), deleting it", id, archive.Settings.StorageType, archive.Id))
+			return nil
+		}
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("cannot delete sub account %d, it has no enabled archive", id),
+		Detail: "archive_before_delete is enabled, so the sub account is deleted only when an archive is configured and enabled for it. " +
+			"Configure and enable an archive in the account of archive_api_token, or set archive_before_delete = false and apply.",
+	}}
+}
+
 func setSubAccount(d *schema.ResourceData, subAccount *sub_accounts.SubAccount) {
 	d.Set(subAccountId, subAccount.AccountId)
 	d.Set(subAccountName, subAccount.AccountName)
@@ -284,6 +393,75 @@ func setSubAccount(d *schema.ResourceData, subAccount *sub_accounts.SubAccount)
 	d.Set(subAccountSharingObjectsAccounts, sharingObjectAccounts)
 }
 
+// setSubAccountSharingObjectsAccountNames keeps in the names attribute the accounts that are still shared,
+// and removes them from the ids attribute (see removeNamedIds). configuredIds are the ids in the state before the read.
+func setSubAccountSharingObjectsAccountNames(d *schema.ResourceData, subAccount *sub_accounts.SubAccount, configuredIds []int32) {
+	configuredNames := getSubAccountSharingObjectsAccountNames(d)
+	if len(configuredNames) == 0 {
+		return
+	}
+
+	isConfigured := make(map[string]bool)
+	for _, name := range configuredNames {
+		isConfigured[name] = true
+	}
+
+	sharedNames := make([]string, 0)
+	sharedIds := make([]int32, 0, len(subAccount.SharingObjectsAccounts))
+	var namedIds []int32
+	for _, account := range subAccount.SharingObjectsAccounts {
+		sharedIds = append(sharedIds, account.AccountId)
+		if isConfigured[account.AccountName] {
+			sharedNames = append(sharedNames, account.AccountName)
+			namedIds = append(namedIds, account.AccountId)
+		}
+	}
+
+	d.Set(subAccountSharingObjectsAccountNames, sharedNames)
+	d.Set(subAccountSharingObjectsAccounts, removeNamedIds(sharedIds, namedIds, configuredIds))
+}
+
+func getSubAccountSharingObjectsAccountNames(d *schema.ResourceData) []string {
+	return utils.ParseInterfaceSliceToStringSlice(d.Get(subAccountSharingObjectsAccountNames).(*schema.Set).List())
+}
+
+// subAccountIdsByNames resolves account names to ids through the sub accounts list.
+// The ids are sorted, so the order in which the names are resolved doesn't cause a diff.
+func subAccountIdsByNames(m interface{}, names []string) ([]int32, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	subAccounts, err := subAccountClient(m).ListSubAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("could not list sub-accounts: %v", err)
+	}
+
+	idsByName := make(map[string][]int32)
+	for _, subAccount := range subAccounts {
+		idsByName[subAccount.AccountName] = append(idsByName[subAccount.AccountName], subAccount.AccountId)
+	}
+
+	ids := make([]int32, 0, len(names))
+	for _, name := range names {
+		matching := idsByName[name]
+		switch len(matching) {
+		case 0:
+			return nil, fmt.Errorf("couldn't find sub-account with name %q", name)
+		case 1:
+			ids = append(ids, matching[0])
+		default:
+			return nil, fmt.Errorf("found %d sub-accounts with name %q (ids: %v), use the account id instead", len(matching), name, matching)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids, nil
+}
+
 func setTokenAndId(d *schema.ResourceData, m interface{}, id int64) error {
 	accountToken, okToken := d.GetOk(subAccountToken)
 	accountId, okId := d.GetOk(subAccountId)
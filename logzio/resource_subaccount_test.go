@@ -1,12 +1,16 @@
 package logzio
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/logzio/logzio_terraform_client/sub_accounts"
 	"github.com/logzio/logzio_terraform_provider/logzio/utils"
 )
 
@@ -37,7 +41,7 @@ func TestAccLogzioSubaccount_CreateSubaccount(t *testing.T) {
 				ResourceName:            "logzio_subaccount.test_subaccount",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{subAccountEmail},
+				ImportStateVerifyIgnore: []string{subAccountEmail, subAccountDeletionProtection},
 			},
 		},
 	})
@@ -68,7 +72,7 @@ func TestAccLogzioSubaccount_CreateSubaccountEmptySharingObject(t *testing.T) {
 				ResourceName:            "logzio_subaccount.test_subaccount",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{subAccountEmail},
+				ImportStateVerifyIgnore: []string{subAccountEmail, subAccountDeletionProtection},
 			},
 		},
 	})
@@ -168,7 +172,7 @@ func TestAccLogzioSubaccount_CreateSubaccountWarmRetention(t *testing.T) {
 				ResourceName:            "logzio_subaccount.test_subaccount",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{subAccountEmail},
+				ImportStateVerifyIgnore: []string{subAccountEmail, subAccountDeletionProtection},
 			},
 		},
 	})
@@ -235,7 +239,7 @@ func TestAccLogzioSubaccount_CreateSubaccountConsumption(t *testing.T) {
 				ResourceName:            "logzio_subaccount.test_subaccount_consumption",
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{subAccountEmail},
+				ImportStateVerifyIgnore: []string{subAccountEmail, subAccountDeletionProtection},
 			},
 		},
 	})
@@ -306,7 +310,7 @@ func TestAccLogzioSubaccount_UpdateSubaccount(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{subAccountEmail},
+				ImportStateVerifyIgnore: []string{subAccountEmail, subAccountDeletionProtection},
 			},
 		},
 	})
@@ -315,6 +319,7 @@ func TestAccLogzioSubaccount_UpdateSubaccount(t *testing.T) {
 func testAccCheckLogzioSubaccountConfig(email string, accountName string, accountId string) string {
 	return fmt.Sprintf(`
 resource "logzio_subaccount" "test_subaccount" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = 2
@@ -331,6 +336,7 @@ resource "logzio_subaccount" "test_subaccount" {
 func testAccCheckLogzioWarmSubaccountConfig(email string, accountName string, accountId string, retention int, snapRetention int) string {
 	return fmt.Sprintf(`
 resource "logzio_subaccount" "test_subaccount" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = %d
@@ -349,6 +355,7 @@ resource "logzio_subaccount" "test_subaccount" {
 func testAccCheckLogzioConsumptionSubaccountConfig(email, accountName, accountId, isFlexible string, softLimitGb float32) string {
 	return fmt.Sprintf(`
 resource "logzio_subaccount" "test_subaccount_consumption" {
+  deletion_protection = false
   email = "%s"
   account_name = "%s"
   retention_days = 2
@@ -363,3 +370,210 @@ resource "logzio_subaccount" "test_subaccount_consumption" {
 }
 `, email, accountName, accountId, isFlexible, softLimitGb)
 }
+
+func TestAccLogzioSubaccount_DeletionProtection(t *testing.T) {
+	accountId := os.Getenv(envLogzioAccountId)
+	email := os.Getenv(envLogzioEmail)
+	accountName := "test_deletion_protection"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckAccountId(t)
+			testAccPreCheckEmail(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioSubaccountDeletionProtectionConfig(email, accountName, accountId, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"logzio_subaccount.test_subaccount", subAccountDeletionProtection, "true"),
+				),
+			},
+			{
+				// Removing the resource from the configuration destroys it
+				Config:      `# no resources`,
+				ExpectError: regexp.MustCompile("deletion_protection is enabled"),
+			},
+			{
+				Config: testAccCheckLogzioSubaccountDeletionProtectionConfig(email, accountName, accountId, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"logzio_subaccount.test_subaccount", subAccountDeletionProtection, "false"),
+					resource.TestCheckResourceAttr(
+						"logzio_subaccount.test_subaccount", subAccountName, accountName),
+				),
+			},
+		},
+	})
+}
+
+func TestSubAccount_DeleteWithDeletionProtection(t *testing.T) {
+	d := resourceSubAccount().TestResourceData()
+	d.SetId("12345")
+	d.Set(subAccountDeletionProtection, true)
+
+	// Blocked before any API call, so the provider config isn't used
+	diags := resourceSubAccountDelete(context.Background(), d, Config{})
+	if !diags.HasError() {
+		t.Fatal("expected delete to fail when deletion_protection is enabled")
+	}
+	if !strings.Contains(diags[0].Summary, "deletion_protection is enabled") {
+		t.Errorf("unexpected summary: %s", diags[0].Summary)
+	}
+	if d.Id() != "12345" {
+		t.Errorf("expected the id to be kept, got %q", d.Id())
+	}
+}
+
+// testUnknownVariableValue is the value the SDK uses in raw configs for values that aren't known yet
+const testUnknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestSubAccount_CustomizeSubAccountArchiveBeforeDelete(t *testing.T) {
+	cases := map[string]struct {
+		config      map[string]interface{}
+		expectError bool
+	}{
+		"archive before delete without api token": {
+			config:      map[string]interface{}{subAccountArchiveBeforeDelete: true},
+			expectError: true,
+		},
+		"archive before delete with api token": {
+			config: map[string]interface{}{subAccountArchiveBeforeDelete: true, subAccountArchiveApiToken: "some-token"},
+		},
+		"archive before delete with unknown api token": {
+			config: map[string]interface{}{subAccountArchiveBeforeDelete: true, subAccountArchiveApiToken: testUnknownVariableValue},
+		},
+		"no archive before delete": {
+			config: map[string]interface{}{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				subAccountEmail:         "user@example.com",
+				subAccountName:          "test",
+				subAccountRetentionDays: 2,
+			}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+
+			_, err := resourceSubAccount().SimpleDiff(context.Background(),
+				&terraform.InstanceState{}, terraform.NewResourceConfigRaw(config), nil)
+			if (err != nil) != tc.expectError {
+				t.Errorf("expected error to be %t, got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func testAccCheckLogzioSubaccountDeletionProtectionConfig(email string, accountName string, accountId string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "logzio_subaccount" "test_subaccount" {
+  deletion_protection = %t
+  email = "%s"
+  account_name = "%s"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_accounts = [
+    %s
+  ]
+}
+`, deletionProtection, email, accountName, accountId)
+}
+
+func TestAccLogzioSubaccount_SharingObjectsAccountNames(t *testing.T) {
+	email := os.Getenv(envLogzioEmail)
+	sharedAccountName := "test_shared_by_name_" + getRandomId()
+	resourceName := "logzio_subaccount.test_subaccount_by_name"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckEmail(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioSubaccountSharingNamesConfig(email, sharedAccountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "sharing_objects_account_names.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "sharing_objects_account_names.*", sharedAccountName),
+					// Accounts referenced by name don't show up in the ids
+					resource.TestCheckResourceAttr(resourceName, "sharing_objects_accounts.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSubAccount_SetSubAccountSharingObjectsAccountNames(t *testing.T) {
+	d := resourceSubAccount().TestResourceData()
+	d.Set(subAccountSharingObjectsAccountNames, []string{"by_name", "no_longer_shared"})
+
+	setSubAccountSharingObjectsAccountNames(d, &sub_accounts.SubAccount{
+		SharingObjectsAccounts: []sub_accounts.SharingAccount{
+			{AccountId: 1, AccountName: "by_id"},
+			{AccountId: 2, AccountName: "by_name"},
+		},
+	}, nil)
+
+	names := getSubAccountSharingObjectsAccountNames(d)
+	if len(names) != 1 || names[0] != "by_name" {
+		t.Errorf("expected the names to be [by_name], got %v", names)
+	}
+	ids := d.Get(subAccountSharingObjectsAccounts).([]interface{})
+	if len(ids) != 1 || ids[0].(int) != 1 {
+		t.Errorf("expected the ids to be [1], got %v", ids)
+	}
+}
+
+func TestSubAccount_SetSubAccountSharingObjectsAccountNamesByIdAndName(t *testing.T) {
+	d := resourceSubAccount().TestResourceData()
+	d.Set(subAccountSharingObjectsAccountNames, []string{"by_both"})
+
+	setSubAccountSharingObjectsAccountNames(d, &sub_accounts.SubAccount{
+		SharingObjectsAccounts: []sub_accounts.SharingAccount{
+			{AccountId: 1, AccountName: "by_id"},
+			{AccountId: 2, AccountName: "by_both"},
+		},
+	}, []int32{1, 2})
+
+	names := getSubAccountSharingObjectsAccountNames(d)
+	if len(names) != 1 || names[0] != "by_both" {
+		t.Errorf("expected the names to be [by_both], got %v", names)
+	}
+	ids := d.Get(subAccountSharingObjectsAccounts).([]interface{})
+	if len(ids) != 2 || ids[0].(int) != 1 || ids[1].(int) != 2 {
+		t.Errorf("expected an account referenced both by id and by name to be kept in the ids, got %v", ids)
+	}
+}
+
+func testAccCheckLogzioSubaccountSharingNamesConfig(email string, sharedAccountName string) string {
+	return fmt.Sprintf(`
+resource "logzio_subaccount" "test_shared_subaccount" {
+  deletion_protection = false
+  email = "%s"
+  account_name = "%s"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_accounts = []
+}
+
+resource "logzio_subaccount" "test_subaccount_by_name" {
+  deletion_protection = false
+  email = "%s"
+  account_name = "%s_sharing"
+  retention_days = 2
+  max_daily_gb = 1
+  sharing_objects_account_names = [
+    logzio_subaccount.test_shared_subaccount.account_name
+  ]
+}
+`, email, sharedAccountName, email, sharedAccountName)
+}
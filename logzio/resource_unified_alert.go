@@ -28,6 +28,7 @@ const (
 	unifiedAlertEnabled                             = "enabled"
 	unifiedAlertRca                                 = "rca"
 	unifiedAlertRcaNotificationEndpointIds          = "rca_notification_endpoint_ids"
+	unifiedAlertRcaNotificationEndpointNames        = "rca_notification_endpoint_names"
 	unifiedAlertUseAlertNotificationEndpointsForRca = "use_alert_notification_endpoints_for_rca"
 	unifiedAlertCreatedAt                           = "created_at"
 	unifiedAlertUpdatedAt                           = "updated_at"
@@ -44,8 +45,9 @@ const (
 	logAlertOutputType                         = "type"
 
 	// Recipients fields
-	recipientsEmails                  = "emails"
-	recipientsNotificationEndpointIds = "notification_endpoint_ids"
+	recipientsEmails                    = "emails"
+	recipientsNotificationEndpointIds   = "notification_endpoint_ids"
+	recipientsNotificationEndpointNames = "notification_endpoint_names"
 
 	// SubComponent fields
 	subComponentQueryDefinition = "query_definition"
@@ -120,8 +122,20 @@ const (
 	metricQueryDefinitionPromqlQuery   = "promql_query"
 
 	unifiedAlertRetryAttempts = 8
+
+	unifiedAlertLogAlertEndpointNamesPath    = unifiedAlertLogAlert + ".0." + logAlertOutput + ".0." + logAlertOutputRecipients + ".0." + recipientsNotificationEndpointNames
+	unifiedAlertMetricAlertEndpointNamesPath = unifiedAlertMetricAlert + ".0." + metricAlertRecipients + ".0." + recipientsNotificationEndpointNames
+	unifiedAlertLogAlertEndpointIdsPath      = unifiedAlertLogAlert + ".0." + logAlertOutput + ".0." + logAlertOutputRecipients + ".0." + recipientsNotificationEndpointIds
+	unifiedAlertMetricAlertEndpointIdsPath   = unifiedAlertMetricAlert + ".0." + metricAlertRecipients + ".0." + recipientsNotificationEndpointIds
 )
 
+// unifiedAlertEndpointIdsPaths maps the schema path of each notification endpoint names attribute to its matching ids attribute
+var unifiedAlertEndpointIdsPaths = map[string]string{
+	unifiedAlertRcaNotificationEndpointNames: unifiedAlertRcaNotificationEndpointIds,
+	unifiedAlertLogAlertEndpointNamesPath:    unifiedAlertLogAlertEndpointIdsPath,
+	unifiedAlertMetricAlertEndpointNamesPath: unifiedAlertMetricAlertEndpointIdsPath,
+}
+
 // unifiedAlertClient returns the unified alert client with the api token from the provider
 func unifiedAlertClient(m interface{}) *unified_alerts.UnifiedAlertsClient {
 	var client *unified_alerts.UnifiedAlertsClient
@@ -197,6 +211,13 @@ func resourceUnifiedAlert() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
+			unifiedAlertRcaNotificationEndpointNames: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			unifiedAlertUseAlertNotificationEndpointsForRca: {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -335,6 +356,13 @@ func resourceRecipients() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
+			recipientsNotificationEndpointNames: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -565,6 +593,10 @@ func resourceUnifiedAlertCreate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if err = addUnifiedAlertEndpointNames(m, d, &createAlert); err != nil {
+		return diag.FromErr(err)
+	}
+
 	jsonBytes, _ := json.Marshal(createAlert)
 	tflog.Debug(ctx, fmt.Sprintf("Creating unified alert: %s", string(jsonBytes)))
 
@@ -598,6 +630,7 @@ func resourceUnifiedAlertRead(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	removeUnifiedAlertEndpointNames(ctx, m, d, alert)
 	return setUnifiedAlert(d, alert)
 }
 
@@ -610,6 +643,10 @@ func resourceUnifiedAlertUpdate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	if err = addUnifiedAlertEndpointNames(m, d, &createAlert); err != nil {
+		return diag.FromErr(err)
+	}
+
 	jsonBytes, _ := json.Marshal(createAlert)
 	tflog.Debug(ctx, fmt.Sprintf("Updating unified alert %s: %s", alertId, string(jsonBytes)))
 
@@ -668,6 +705,64 @@ func getUrlTypeFromAlertType(alertType string) string {
 	return unified_alerts.UrlTypeMetrics
 }
 
+// unifiedAlertEndpointIdsByNamesPath maps the schema path of each notification endpoint names attribute
+// to the alert's matching list of notification endpoint ids
+func unifiedAlertEndpointIdsByNamesPath(rcaIds *[]int, logAlert *unified_alerts.LogAlertConfig, metricAlert *unified_alerts.MetricAlertConfig) map[string]*[]int {
+	idsByNamesPath := map[string]*[]int{
+		unifiedAlertRcaNotificationEndpointNames: rcaIds,
+	}
+
+	if logAlert != nil {
+		idsByNamesPath[unifiedAlertLogAlertEndpointNamesPath] = &logAlert.Output.Recipients.NotificationEndpointIds
+	}
+
+	if metricAlert != nil {
+		idsByNamesPath[unifiedAlertMetricAlertEndpointNamesPath] = &metricAlert.Recipients.NotificationEndpointIds
+	}
+
+	return idsByNamesPath
+}
+
+// addUnifiedAlertEndpointNames adds to the alert the ids of the notification endpoints referenced by name
+func addUnifiedAlertEndpointNames(m interface{}, d *schema.ResourceData, alert *unified_alerts.CreateUnifiedAlert) error {
+	idsByNamesPath := unifiedAlertEndpointIdsByNamesPath(&alert.RcaNotificationEndpointIds, alert.LogAlert, alert.MetricAlert)
+	for namesPath, ids := range idsByNamesPath {
+		names := interfaceSliceToStringSlice(d.Get(namesPath).([]interface{}))
+		if len(names) == 0 {
+			continue
+		}
+
+		namedEndpointIds, err := endpointIdsByTitles(m, names)
+		if err != nil {
+			return fmt.Errorf("could not resolve %s: %v", namesPath, err)
+		}
+
+		*ids = mergeIds(*ids, namedEndpointIds)
+	}
+
+	return nil
+}
+
+// removeUnifiedAlertEndpointNames removes from the alert the ids of the notification endpoints referenced by name (see removeNamedIds)
+func removeUnifiedAlertEndpointNames(ctx context.Context, m interface{}, d *schema.ResourceData, alert *unified_alerts.UnifiedAlert) {
+	idsByNamesPath := unifiedAlertEndpointIdsByNamesPath(&alert.RcaNotificationEndpointIds, alert.LogAlert, alert.MetricAlert)
+	for namesPath, ids := range idsByNamesPath {
+		names := interfaceSliceToStringSlice(d.Get(namesPath).([]interface{}))
+		if len(names) == 0 {
+			continue
+		}
+
+		namedEndpointIds, err := endpointIdsByTitles(m, names)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("could not resolve %s: %v", namesPath, err))
+			continue
+		}
+
+		configuredIds := interfaceSliceToIntSlice(d.Get(unifiedAlertEndpointIdsPaths[namesPath]).([]interface{}))
+		*ids = removeNamedIds(*ids, namedEndpointIds, configuredIds)
+	}
+}
+
 func buildCreateUnifiedAlert(d *schema.ResourceData) (unified_alerts.CreateUnifiedAlert, string, error) {
 	alertType := d.Get(unifiedAlertType).(string)
 	urlType := getUrlTypeFromAlertType(alertType)
@@ -972,6 +1067,10 @@ func setLogAlert(d *schema.ResourceData, logAlert *unified_alerts.LogAlertConfig
 	if len(logAlert.Output.Recipients.NotificationEndpointIds) > 0 {
 		recipientsMap[recipientsNotificationEndpointIds] = logAlert.Output.Recipients.NotificationEndpointIds
 	}
+	// Names are not returned from the API, keep the configured ones
+	if names, ok := d.GetOk(unifiedAlertLogAlertEndpointNamesPath); ok {
+		recipientsMap[recipientsNotificationEndpointNames] = names
+	}
 	outputMap[logAlertOutputRecipients] = []interface{}{recipientsMap}
 	logAlertMap[logAlertOutput] = []interface{}{outputMap}
 
@@ -1120,6 +1219,10 @@ func setMetricAlert(d *schema.ResourceData, metricAlert *unified_alerts.MetricAl
 		recipientsEmails:                  metricAlert.Recipients.Emails,
 		recipientsNotificationEndpointIds: metricAlert.Recipients.NotificationEndpointIds,
 	}
+	// Names are not returned from the API, keep the configured ones
+	if names, ok := d.GetOk(unifiedAlertMetricAlertEndpointNamesPath); ok {
+		recipientsMap[recipientsNotificationEndpointNames] = names
+	}
 	metricAlertMap[metricAlertRecipients] = []interface{}{recipientsMap}
 
 	return d.Set(unifiedAlertMetricAlert, []interface{}{metricAlertMap})
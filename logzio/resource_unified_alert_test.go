@@ -84,6 +84,26 @@ func TestAccLogzioUnifiedAlert_MetricAlertMathExpression(t *testing.T) {
 	})
 }
 
+func TestAccLogzioUnifiedAlert_LogAlertEndpointNames(t *testing.T) {
+	endpointTitle := "tf-unified-alert-endpoint-" + getRandomId()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckApiToken(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: getUnifiedLogAlertEndpointNamesConfig(endpointTitle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("logzio_unified_alert.test_log_alert_endpoint_names", "alert_id"),
+					resource.TestCheckResourceAttr("logzio_unified_alert.test_log_alert_endpoint_names", "log_alert.0.output.0.recipients.0.notification_endpoint_names.0", endpointTitle),
+					resource.TestCheckResourceAttr("logzio_unified_alert.test_log_alert_endpoint_names", "log_alert.0.output.0.recipients.0.notification_endpoint_ids.#", "0"),
+					resource.TestCheckResourceAttr("logzio_unified_alert.test_log_alert_endpoint_names", "rca_notification_endpoint_names.0", endpointTitle),
+				),
+			},
+		},
+	})
+}
+
 func testCheckUnifiedAlertDestroy(s *terraform.State) error {
 	client := unifiedAlertClient(testAccProvider.Meta())
 
@@ -282,3 +302,55 @@ resource "logzio_unified_alert" "test_metric_math_alert" {
 }
 `, datasourceUid, datasourceUid, email)
 }
+
+func getUnifiedLogAlertEndpointNamesConfig(endpointTitle string) string {
+	return fmt.Sprintf(`
+resource "logzio_endpoint" "test_unified_alert_endpoint" {
+  title         = "%s"
+  endpoint_type = "slack"
+  description   = "endpoint referenced by name from a unified alert"
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/1"
+  }
+}
+
+resource "logzio_unified_alert" "test_log_alert_endpoint_names" {
+  title                           = "Test Log Alert Endpoint Names"
+  type                            = "LOG_ALERT"
+  rca                             = true
+  rca_notification_endpoint_names = [logzio_endpoint.test_unified_alert_endpoint.title]
+
+  log_alert {
+    search_timeframe_minutes = 5
+
+    output {
+      type = "JSON"
+
+      recipients {
+        notification_endpoint_names = [logzio_endpoint.test_unified_alert_endpoint.title]
+      }
+    }
+
+    sub_components {
+      query_definition {
+        query                        = "level:ERROR"
+        should_query_on_all_accounts = true
+
+        aggregation {
+          aggregation_type = "COUNT"
+        }
+      }
+
+      trigger {
+        operator = "GREATER_THAN"
+
+        severity_threshold_tiers {
+          severity  = "HIGH"
+          threshold = 10
+        }
+      }
+    }
+  }
+}
+`, endpointTitle)
+}
@@ -0,0 +1,478 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/logzio/logzio_terraform_client/users"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+const (
+	usersAccountId                 = "account_id"
+	usersUsers                     = "users"
+	usersSsoUsernameRegex          = "sso_username_regex"
+	usersSuspendRemovedUsers       = "suspend_removed_users"
+	usersIgnoreExternalSuspensions = "ignore_external_suspensions"
+	usersUndeclaredUsers           = "undeclared_users"
+	usersCreatedUsers              = "created_users"
+)
+
+// resourceUsers manages the users of an account as one resource.
+// Users are matched to the users in Logz.io by username, so usernames must be unique within the resource.
+// Users that exist in the account but aren't declared are only reported, never changed.
+// Only users that were created by the resource are deleted or suspended, declared users that already existed are only adopted.
+func resourceUsers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUsersCreate,
+		ReadContext:   resourceUsersRead,
+		UpdateContext: resourceUsersUpdate,
+		DeleteContext: resourceUsersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUsersImport,
+		},
+		CustomizeDiff: validateUsersUsernames,
+		Schema: map[string]*schema.Schema{
+			usersAccountId: {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			usersSsoUsernameRegex: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			usersSuspendRemovedUsers: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			usersIgnoreExternalSuspensions: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			usersUsers: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						userId: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						userUsername: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						userFullName: {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						userRole: {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: utils.ValidateUserRoleUser,
+						},
+						userActive: {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			usersUndeclaredUsers: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			usersCreatedUsers: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourceUsersCreate applies the declared users of a new resource. Declared users that already exist in the account are adopted
+func resourceUsersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := applyUsers(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(d.Get(usersAccountId).(int)))
+	return nil
+}
+
+// resourceUsersRead gets all the users of the account with a single list
+func resourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId := int32(d.Get(usersAccountId).(int))
+	existing, err := listAccountUsers(usersClient(m), accountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	byUsername := usersByUsername(existing)
+	ignoreExternalSuspensions := d.Get(usersIgnoreExternalSuspensions).(bool)
+
+	// Keep the order of the users in the state, so reordering in the API doesn't show as a diff
+	declared := getUsersFromSchema(d)
+	current := make([]users.User, 0, len(declared))
+	for _, user := range declared {
+		existingUser, ok := byUsername[user.UserName]
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("could not find user %s in account %d", user.UserName, accountId))
+			continue
+		}
+
+		existingUser.Active = getUserActiveForState(existingUser, user.Active, ignoreExternalSuspensions)
+		current = append(current, existingUser)
+	}
+
+	undeclared, err := getUndeclaredUsernames(existing, declared, d.Get(usersSsoUsernameRegex).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setUsers(d, current)
+	d.Set(usersUndeclaredUsers, undeclared)
+	return nil
+}
+
+// resourceUsersUpdate applies only the differences between the declared users and Logz.io
+func resourceUsersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := applyUsers(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceUsersDelete deletes, or suspends, the users managed by the resource that were created by it.
+// Adopted users are left as they are.
+func resourceUsersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	suspend := d.Get(usersSuspendRemovedUsers).(bool)
+	created := getCreatedUsernames(d)
+	for _, user := range getUsersFromSchema(d) {
+		if user.Id == 0 {
+			continue
+		}
+
+		if !created[user.UserName] {
+			tflog.Info(ctx, fmt.Sprintf("leaving user %d (%s), it was adopted and not created by the resource", user.Id, user.UserName))
+			continue
+		}
+
+		if err := removeUser(ctx, m, user, suspend); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceUsersImport imports the account by its id, without any users.
+// The declared users are adopted on the next apply, so importing never removes users that aren't declared,
+// and destroying the resource never deletes them.
+func resourceUsersImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import id %s, expected account_id", d.Id())
+	}
+
+	d.Set(usersAccountId, accountId)
+	d.Set(usersSuspendRemovedUsers, false)
+	d.Set(usersIgnoreExternalSuspensions, false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateUsersUsernames checks that usernames are unique
+func validateUsersUsernames(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	usernames := make(map[string]bool)
+	for i, raw := range d.Get(usersUsers).([]interface{}) {
+		if raw == nil || !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", usersUsers, i, userUsername)) {
+			continue
+		}
+
+		username := raw.(map[string]interface{})[userUsername].(string)
+		if usernames[username] {
+			return fmt.Errorf("%s.%d.%s: found more than one user with username %s, usernames must be unique", usersUsers, i, userUsername, username)
+		}
+		usernames[username] = true
+	}
+
+	return nil
+}
+
+// applyUsers diffs the declared users against the users of the account, and creates, updates, suspends and unsuspends only what changed.
+// Users that were removed from the resource are deleted, or suspended if suspend_removed_users is set, if the resource created them.
+func applyUsers(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := usersClient(m)
+	accountId := int32(d.Get(usersAccountId).(int))
+	existing, err := listAccountUsers(client, accountId)
+	if err != nil {
+		return err
+	}
+
+	byUsername := usersByUsername(existing)
+	previouslyManaged := make(map[string]users.User)
+	if !d.IsNewResource() {
+		oldUsers, _ := d.GetChange(usersUsers)
+		for _, user := range usersFromList(oldUsers.([]interface{})) {
+			previouslyManaged[user.UserName] = user
+		}
+	}
+
+	created := getCreatedUsernames(d)
+	ignoreExternalSuspensions := d.Get(usersIgnoreExternalSuspensions).(bool)
+	desired := getUsersFromSchema(d)
+	results := make([]users.User, 0, len(desired))
+	for _, user := range desired {
+		current, exists := byUsername[user.UserName]
+		req := users.CreateUpdateUser{
+			UserName:  user.UserName,
+			FullName:  user.FullName,
+			AccountId: accountId,
+			Role:      user.Role,
+		}
+
+		if !exists {
+			tflog.Info(ctx, fmt.Sprintf("creating user %s in account %d", user.UserName, accountId))
+			createdUser, err := client.CreateUser(req)
+			if err != nil {
+				return fmt.Errorf("could not create user %s: %v", user.UserName, err)
+			}
+
+			current = users.User{Id: createdUser.Id, UserName: user.UserName, FullName: user.FullName, AccountId: accountId, Role: user.Role, Active: true}
+			created[user.UserName] = true
+		} else if current.FullName != user.FullName || current.Role != user.Role {
+			tflog.Info(ctx, fmt.Sprintf("updating user %d (%s)", current.Id, user.UserName))
+			if _, err = client.UpdateUser(current.Id, req); err != nil {
+				return fmt.Errorf("could not update user %d (%s): %v", current.Id, user.UserName, err)
+			}
+
+			current.FullName = user.FullName
+			current.Role = user.Role
+		}
+
+		// A suspension made outside of terraform is kept, unless the declared active value changed
+		previous, wasManaged := previouslyManaged[user.UserName]
+		changeActivation := !ignoreExternalSuspensions || !exists || (wasManaged && previous.Active != user.Active)
+		if changeActivation && current.Active != user.Active {
+			tflog.Info(ctx, fmt.Sprintf("changing activation of user %d (%s) to %t", current.Id, user.UserName, user.Active))
+			if err = changeUserActivation(current.Id, user.Active, m); err != nil {
+				return fmt.Errorf("could not change activation of user %d (%s): %v", current.Id, user.UserName, err)
+			}
+			current.Active = user.Active
+		}
+
+		current.Active = getUserActiveForState(current, user.Active, ignoreExternalSuspensions)
+		results = append(results, current)
+		delete(previouslyManaged, user.UserName)
+	}
+
+	suspend := d.Get(usersSuspendRemovedUsers).(bool)
+	for _, username := range sortedUsernames(previouslyManaged) {
+		current, exists := byUsername[username]
+		if !exists {
+			delete(created, username)
+			continue
+		}
+
+		if !created[username] {
+			tflog.Info(ctx, fmt.Sprintf("leaving removed user %d (%s), it was adopted and not created by the resource", current.Id, username))
+			continue
+		}
+
+		if err = removeUser(ctx, m, current, suspend); err != nil {
+			return err
+		}
+
+		// A suspended user is still the resource's, so it can be unsuspended and later deleted if it's declared again
+		if !suspend {
+			delete(created, username)
+		}
+	}
+
+	undeclared, err := getUndeclaredUsernames(existing, desired, d.Get(usersSsoUsernameRegex).(string))
+	if err != nil {
+		return err
+	}
+
+	setUsers(d, results)
+	setCreatedUsernames(d, created)
+	d.Set(usersUndeclaredUsers, undeclared)
+	return nil
+}
+
+// removeUser deletes a user that is no longer managed, or suspends it if suspend is set
+func removeUser(ctx context.Context, m interface{}, user users.User, suspend bool) error {
+	var err error
+	if suspend {
+		if !user.Active {
+			return nil
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("suspending removed user %d (%s)", user.Id, user.UserName))
+		err = usersClient(m).SuspendUser(user.Id)
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("deleting removed user %d (%s)", user.Id, user.UserName))
+		err = usersClient(m).DeleteUser(user.Id)
+	}
+
+	if err != nil && !strings.Contains(err.Error(), "missing user") {
+		return fmt.Errorf("could not remove user %d (%s): %v", user.Id, user.UserName, err)
+	}
+
+	return nil
+}
+
+// getUserActiveForState returns the active value to keep in the state.
+// When suspensions made outside of terraform are ignored, a suspended user keeps its declared value, so it doesn't show as a diff
+func getUserActiveForState(current users.User, declaredActive bool, ignoreExternalSuspensions bool) bool {
+	if ignoreExternalSuspensions && !current.Active {
+		return declaredActive
+	}
+
+	return current.Active
+}
+
+// getUndeclaredUsernames returns the sorted usernames of the account's users that aren't declared, except for SSO users
+func getUndeclaredUsernames(existing []users.User, declared []users.User, ssoUsernameRegex string) ([]string, error) {
+	var ssoUsername *regexp.Regexp
+	if ssoUsernameRegex != "" {
+		var err error
+		if ssoUsername, err = regexp.Compile(ssoUsernameRegex); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", usersSsoUsernameRegex, err)
+		}
+	}
+
+	isDeclared := make(map[string]bool, len(declared))
+	for _, user := range declared {
+		isDeclared[user.UserName] = true
+	}
+
+	undeclared := make([]string, 0)
+	for _, user := range existing {
+		if isDeclared[user.UserName] || (ssoUsername != nil && ssoUsername.MatchString(user.UserName)) {
+			continue
+		}
+
+		undeclared = append(undeclared, user.UserName)
+	}
+
+	sort.Strings(undeclared)
+	return undeclared, nil
+}
+
+// listAccountUsers gets all the users of an account
+func listAccountUsers(client *users.UsersClient, accountId int32) ([]users.User, error) {
+	list, err := client.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]users.User, 0, len(list))
+	for _, user := range list {
+		if user.AccountId == accountId {
+			result = append(result, user)
+		}
+	}
+
+	return result, nil
+}
+
+func usersByUsername(list []users.User) map[string]users.User {
+	byUsername := make(map[string]users.User, len(list))
+	for _, user := range list {
+		byUsername[user.UserName] = user
+	}
+
+	return byUsername
+}
+
+func sortedUsernames(byUsername map[string]users.User) []string {
+	usernames := make([]string, 0, len(byUsername))
+	for username := range byUsername {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+	return usernames
+}
+
+// getCreatedUsernames gets the usernames of the users that were created by the resource
+func getCreatedUsernames(d *schema.ResourceData) map[string]bool {
+	created := make(map[string]bool)
+	for _, username := range d.Get(usersCreatedUsers).([]interface{}) {
+		created[username.(string)] = true
+	}
+
+	return created
+}
+
+func setCreatedUsernames(d *schema.ResourceData, created map[string]bool) {
+	usernames := make([]string, 0, len(created))
+	for username := range created {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+	d.Set(usersCreatedUsers, usernames)
+}
+
+// getUsersFromSchema gets the users of the resource from the schema
+func getUsersFromSchema(d *schema.ResourceData) []users.User {
+	return usersFromList(d.Get(usersUsers).([]interface{}))
+}
+
+func usersFromList(rawUsers []interface{}) []users.User {
+	result := make([]users.User, 0, len(rawUsers))
+	for _, raw := range rawUsers {
+		if raw == nil {
+			continue
+		}
+
+		user := raw.(map[string]interface{})
+		result = append(result, users.User{
+			Id:       int32(user[userId].(int)),
+			UserName: user[userUsername].(string),
+			FullName: user[userFullName].(string),
+			Role:     user[userRole].(string),
+			Active:   user[userActive].(bool),
+		})
+	}
+
+	return result
+}
+
+func setUsers(d *schema.ResourceData, list []users.User) {
+	rawUsers := make([]interface{}, 0, len(list))
+	for _, user := range list {
+		rawUsers = append(rawUsers, map[string]interface{}{
+			userId:       int(user.Id),
+			userUsername: user.UserName,
+			userFullName: user.FullName,
+			userRole:     user.Role,
+			userActive:   user.Active,
+		})
+	}
+
+	d.Set(usersUsers, rawUsers)
+}
@@ -0,0 +1,236 @@
+package logzio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/logzio/logzio_terraform_client/users"
+	"github.com/logzio/logzio_terraform_provider/logzio/utils"
+)
+
+func TestAccLogzioUsers_ReconcileUsers(t *testing.T) {
+	accountId, _ := strconv.ParseInt(os.Getenv(envLogzioAccountId), utils.BASE_10, utils.BITSIZE_64)
+	firstUser := "test_resource_users_first@tfacctest.com"
+	secondUser := "test_resource_users_second@tfacctest.com"
+	resourceName := "logzio_users.test_users"
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioUsersConfig(accountId, true, false,
+					testAccUsersUser(firstUser, users.UserRoleReadOnly),
+					testAccUsersUser(secondUser, users.UserRoleReadOnly)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "users.0.username", firstUser),
+					resource.TestCheckResourceAttrSet(resourceName, "users.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "users.1.username", secondUser),
+				),
+			},
+			{
+				// The second user is suspended, so it's now reported as undeclared
+				Config: testAccCheckLogzioUsersConfig(accountId, true, false,
+					testAccUsersUser(firstUser, users.UserRoleRegular)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "users.0.role", users.UserRoleRegular),
+					resource.TestCheckTypeSetElemAttr(resourceName, "undeclared_users.*", secondUser),
+				),
+			},
+			{
+				// The suspended user was created by the resource, so it's unsuspended and both are deleted on destroy
+				Config: testAccCheckLogzioUsersConfig(accountId, false, false,
+					testAccUsersUser(firstUser, users.UserRoleRegular),
+					testAccUsersUser(secondUser, users.UserRoleReadOnly)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "users.1.active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "created_users.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogzioUsers_IgnoreExternalSuspensions(t *testing.T) {
+	accountId, _ := strconv.ParseInt(os.Getenv(envLogzioAccountId), utils.BASE_10, utils.BITSIZE_64)
+	username := "test_resource_users_suspended@tfacctest.com"
+	resourceName := "logzio_users.test_users"
+	var userId int32
+	defer utils.SleepAfterTest()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheckApiToken(t)
+			testAccPreCheckAccountId(t)
+		},
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckLogzioUsersConfig(accountId, false, true,
+					testAccUsersUser(username, users.UserRoleReadOnly)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.0.active", "true"),
+					func(s *terraform.State) error {
+						id, err := strconv.Atoi(s.RootModule().Resources[resourceName].Primary.Attributes["users.0.id"])
+						userId = int32(id)
+						return err
+					},
+				),
+			},
+			{
+				// The user is suspended outside of terraform, which shouldn't show as a diff or be reverted
+				PreConfig: func() {
+					if err := usersClient(testAccConfig()).SuspendUser(userId); err != nil {
+						t.Fatalf("could not suspend user %d: %v", userId, err)
+					}
+				},
+				Config: testAccCheckLogzioUsersConfig(accountId, false, true,
+					testAccUsersUser(username, users.UserRoleReadOnly)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.0.active", "true"),
+					func(s *terraform.State) error {
+						user, err := usersClient(testAccConfig()).GetUser(userId)
+						if err != nil {
+							return err
+						}
+
+						if user.Active {
+							return fmt.Errorf("expected user %d to stay suspended", userId)
+						}
+
+						return nil
+					},
+				),
+			},
+			{
+				// Without ignore_external_suspensions, the user is unsuspended to match the declared value
+				Config: testAccCheckLogzioUsersConfig(accountId, false, false,
+					testAccUsersUser(username, users.UserRoleReadOnly)),
+				Check: resource.ComposeTestCheckFunc(
+					awaitApply(15),
+					resource.TestCheckResourceAttr(resourceName, "users.0.active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUsers_GetUndeclaredUsernames(t *testing.T) {
+	existing := []users.User{
+		{UserName: "sso_b@example.com"},
+		{UserName: "declared@example.com"},
+		{UserName: "manual@example.com"},
+		{UserName: "another@example.com"},
+	}
+	declared := []users.User{{UserName: "declared@example.com"}}
+
+	undeclared, err := getUndeclaredUsernames(existing, declared, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(undeclared, []string{"another@example.com", "manual@example.com", "sso_b@example.com"}) {
+		t.Errorf("unexpected undeclared users: %v", undeclared)
+	}
+
+	undeclared, err = getUndeclaredUsernames(existing, declared, "^sso_")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(undeclared, []string{"another@example.com", "manual@example.com"}) {
+		t.Errorf("expected SSO users not to be reported, got %v", undeclared)
+	}
+}
+
+func TestUsers_GetUserActiveForState(t *testing.T) {
+	cases := map[string]struct {
+		currentActive             bool
+		declaredActive            bool
+		ignoreExternalSuspensions bool
+		expected                  bool
+	}{
+		"suspended outside of terraform":                  {currentActive: false, declaredActive: true, expected: false},
+		"suspended outside of terraform, ignored":         {currentActive: false, declaredActive: true, ignoreExternalSuspensions: true, expected: true},
+		"unsuspended outside of terraform, ignored":       {currentActive: true, declaredActive: false, ignoreExternalSuspensions: true, expected: true},
+		"suspended as declared, with ignored suspensions": {currentActive: false, declaredActive: false, ignoreExternalSuspensions: true, expected: false},
+		"active as declared, without ignored suspensions": {currentActive: true, declaredActive: true, expected: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			active := getUserActiveForState(users.User{Active: tc.currentActive}, tc.declaredActive, tc.ignoreExternalSuspensions)
+			if active != tc.expected {
+				t.Errorf("expected active to be %t", tc.expected)
+			}
+		})
+	}
+}
+
+func TestUsers_ValidateUsersUsernames(t *testing.T) {
+	user := func(username string) map[string]interface{} {
+		return map[string]interface{}{userUsername: username, userFullName: "test", userRole: users.UserRoleReadOnly}
+	}
+
+	cases := map[string]struct {
+		users       []interface{}
+		expectError bool
+	}{
+		"unique usernames":    {users: []interface{}{user("a@example.com"), user("b@example.com")}},
+		"duplicate usernames": {users: []interface{}{user("a@example.com"), user("a@example.com")}, expectError: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{
+				usersAccountId: 1234,
+				usersUsers:     tc.users,
+			}
+
+			_, err := resourceUsers().SimpleDiff(context.Background(),
+				&terraform.InstanceState{}, terraform.NewResourceConfigRaw(config), nil)
+			if (err != nil) != tc.expectError {
+				t.Errorf("expected error to be %t, got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func testAccUsersUser(username string, role string) string {
+	return fmt.Sprintf(`
+  users {
+    username = "%s"
+    fullname = "test users"
+    role = "%s"
+  }`, username, role)
+}
+
+func testAccCheckLogzioUsersConfig(accountId int64, suspendRemovedUsers bool, ignoreExternalSuspensions bool, usersBlocks ...string) string {
+	config := fmt.Sprintf(`
+resource "logzio_users" "test_users" {
+  account_id = %d
+  suspend_removed_users = %t
+  ignore_external_suspensions = %t
+`, accountId, suspendRemovedUsers, ignoreExternalSuspensions)
+	for _, block := range usersBlocks {
+		config += block
+	}
+
+	return config + "\n}\n"
+}
@@ -0,0 +1,9 @@
+resource "logzio_endpoint" "adopt_existing" {
+  endpoint_type = "slack"
+  title = "%s"
+  description = "adopted by terraform"
+  adopt_existing = true
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/2"
+  }
+}
@@ -0,0 +1,10 @@
+
+resource "logzio_endpoint" "adopt_same_title" {
+  endpoint_type = "slack"
+  title = "tf_test_adopt_same_title"
+  description = "adopt an endpoint with an ambiguous title"
+  adopt_existing = true
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/1"
+  }
+}
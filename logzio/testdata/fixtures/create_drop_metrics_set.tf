@@ -0,0 +1,27 @@
+resource "logzio_drop_metrics_set" "%s" {
+  account_id  = %s
+  name_prefix = "tf-test-set-"
+
+  rules {
+    name = "tf-test-set-first"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_first_metric"
+      condition = "EQ"
+    }
+  }
+
+  rules {
+    name = "tf-test-set-second"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_second_metric"
+      condition = "EQ"
+    }
+    filters {
+      name      = "env"
+      value     = "dev|staging"
+      condition = "REGEX_MATCH"
+    }
+  }
+}
@@ -0,0 +1,21 @@
+resource "logzio_drop_metrics_set" "%s" {
+  account_id = %s
+
+  rules {
+    name = "tf-test-set-duplicate"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_first_metric"
+      condition = "EQ"
+    }
+  }
+
+  rules {
+    name = "tf-test-set-duplicate"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_second_metric"
+      condition = "EQ"
+    }
+  }
+}
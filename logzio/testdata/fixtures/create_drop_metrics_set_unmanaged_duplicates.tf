@@ -0,0 +1,40 @@
+resource "logzio_drop_metrics" "%[1]s_unmanaged_first" {
+  account_id = %[2]s
+  name       = "tf-test-set-unmanaged-duplicate"
+
+  filters {
+    name      = "__name__"
+    value     = "tf_test_set_unmanaged_first_metric"
+    condition = "EQ"
+  }
+}
+
+resource "logzio_drop_metrics" "%[1]s_unmanaged_second" {
+  account_id = %[2]s
+  name       = "tf-test-set-unmanaged-duplicate"
+
+  filters {
+    name      = "__name__"
+    value     = "tf_test_set_unmanaged_second_metric"
+    condition = "EQ"
+  }
+}
+
+resource "logzio_drop_metrics_set" "%[1]s" {
+  account_id  = %[2]s
+  name_prefix = "tf-test-set-"
+
+  rules {
+    name = "tf-test-set-first"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_first_metric"
+      condition = "EQ"
+    }
+  }
+
+  depends_on = [
+    logzio_drop_metrics.%[1]s_unmanaged_first,
+    logzio_drop_metrics.%[1]s_unmanaged_second,
+  ]
+}
@@ -0,0 +1,9 @@
+resource "logzio_log_shipping_token" "log_shipping_token_datasource_disabled" {
+  name = "my_disabled_token"
+  enabled = %t
+}
+
+data "logzio_log_shipping_token" "my_disabled_log_shipping_token_datasource" {
+  name = "${logzio_log_shipping_token.log_shipping_token_datasource_disabled.name}"
+  depends_on = ["logzio_log_shipping_token.log_shipping_token_datasource_disabled"]
+}
@@ -0,0 +1,22 @@
+resource "logzio_metrics_rollup_rules" "%s" {
+  account_id = %s
+  metric_type = "COUNTER"
+  rollup_function = "SUM"
+  labels_elimination_method = "GROUP_BY"
+  labels = ["service"]
+
+  filter {
+    expression {
+      comparison = "EQ"
+      name = "service"
+      value = "frontend"
+    }
+    expression {
+      comparison = "REGEX_MATCH"
+      name = "region"
+      value = "us-(east"
+    }
+  }
+
+  new_metric_name_template = "rollup.{{metricName}}"
+}
@@ -0,0 +1,17 @@
+resource "logzio_endpoint" "same_title_first" {
+  endpoint_type = "slack"
+  title = "tf_test_adopt_same_title"
+  description = "first endpoint with this title"
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/1"
+  }
+}
+
+resource "logzio_endpoint" "same_title_second" {
+  endpoint_type = "slack"
+  title = "tf_test_adopt_same_title"
+  description = "second endpoint with this title"
+  slack {
+    url = "https://jsonplaceholder.typicode.com/todos/1"
+  }
+}
@@ -0,0 +1,2 @@
+data "logzio_log_shipping_token_limits" "limits" {
+}
@@ -0,0 +1,9 @@
+resource "logzio_log_shipping_token" "log_shipping_tokens_datasource" {
+  name = "%s"
+}
+
+data "logzio_log_shipping_tokens" "by_name" {
+  name = logzio_log_shipping_token.log_shipping_tokens_datasource.name
+  enabled = true
+  depends_on = ["logzio_log_shipping_token.log_shipping_tokens_datasource"]
+}
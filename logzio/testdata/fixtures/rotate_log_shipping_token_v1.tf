@@ -0,0 +1,5 @@
+resource "logzio_log_shipping_token" "%s" {
+  name = "tf_test_rotate"
+  rotation_trigger = "v1"
+  overlap_hours = 1
+}
@@ -0,0 +1,9 @@
+resource "logzio_drop_filter" "%s" {
+  log_type = "some_type_create"
+
+  field_conditions {
+    field_name = "some_field"
+    value = "some_updated_value"
+  }
+  gb_threshold = 100
+}
@@ -0,0 +1,10 @@
+resource "logzio_drop_filter" "%s" {
+  log_type = "some_type_create"
+  active = false
+
+  field_conditions {
+    field_name = "some_field"
+    value = "some_inactive_value"
+  }
+  gb_threshold = 100
+}
@@ -0,0 +1,29 @@
+resource "logzio_drop_metrics_set" "%s" {
+  account_id  = %s
+  name_prefix = "tf-test-set-"
+
+  rules {
+    name   = "tf-test-set-second"
+    active = false
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_second_metric"
+      condition = "EQ"
+    }
+    filters {
+      name      = "env"
+      value     = "dev"
+      condition = "EQ"
+    }
+  }
+
+  rules {
+    name        = "tf-test-set-third"
+    drop_policy = "DROP_BEFORE_STORING"
+    filters {
+      name      = "__name__"
+      value     = "tf_test_set_third_metric"
+      condition = "EQ"
+    }
+  }
+}
@@ -1,15 +1,23 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/logzio/logzio_terraform_provider/logzio"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return logzio.Provider()
-		},
-	})
+	ctx := context.Background()
+	// The SDK provider is muxed with a framework provider, which serves the ephemeral resources
+	providerServer, err := logzio.MuxServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf5server.Serve("registry.terraform.io/logzio/logzio", providerServer)
+	if err != nil {
+		log.Fatal(err)
+	}
 }